@@ -65,6 +65,21 @@ type BundleManager interface {
 	) (Bundle, error)
 }
 
+// UnfilteredBundleManager is a BundleManager that can also bundle files that are not supported by Snyk Code. It is
+// required to upload an SBOM document.
+type UnfilteredBundleManager interface {
+	BundleManager
+
+	// CreateEmptyUnfiltered works like CreateEmpty but includes files regardless of the supported file types, e.g.
+	// an SBOM document.
+	CreateEmptyUnfiltered(ctx context.Context,
+		rootPath string,
+		filePaths <-chan string,
+	) (bundle Bundle, err error)
+}
+
+var _ UnfilteredBundleManager = (*bundleManager)(nil)
+
 func NewBundleManager(
 	deepcodeClient deepcode.DeepcodeClient,
 	logger *zerolog.Logger,
@@ -90,7 +105,7 @@ func (b *bundleManager) Create(
 	filePaths <-chan string,
 	changedFiles map[string]bool,
 ) (bundle Bundle, err error) {
-	return b.create(ctx, rootPath, filePaths, changedFiles, true)
+	return b.create(ctx, rootPath, filePaths, changedFiles, true, b.IsSupported)
 }
 
 func (b *bundleManager) CreateEmpty(
@@ -99,7 +114,20 @@ func (b *bundleManager) CreateEmpty(
 	filePaths <-chan string,
 	changedFiles map[string]bool,
 ) (bundle Bundle, err error) {
-	return b.create(ctx, rootPath, filePaths, changedFiles, false)
+	return b.create(ctx, rootPath, filePaths, changedFiles, false, b.IsSupported)
+}
+
+func (b *bundleManager) CreateEmptyUnfiltered(
+	ctx context.Context,
+	rootPath string,
+	filePaths <-chan string,
+) (bundle Bundle, err error) {
+	return b.create(ctx, rootPath, filePaths, map[string]bool{}, false, allSupported)
+}
+
+// allSupported accepts every file, regardless of the supported file types.
+func allSupported(context.Context, string) (bool, error) {
+	return true, nil
 }
 
 func (b *bundleManager) create(
@@ -108,6 +136,7 @@ func (b *bundleManager) create(
 	filePaths <-chan string,
 	changedFiles map[string]bool,
 	includeFileContents bool,
+	isSupported func(ctx context.Context, file string) (bool, error),
 ) (bundle Bundle, err error) {
 	span := b.instrumentor.StartSpan(ctx, "code.createBundle")
 	defer b.instrumentor.Finish(span)
@@ -116,19 +145,55 @@ func (b *bundleManager) create(
 	tracker.Begin("Creating file bundle", "Checking and adding files for analysis")
 	defer tracker.End("")
 
-	var limitToFiles []string
-	fileHashes := make(map[string]string)
-	bundleFiles := make(map[string]deepcode.BundleFile)
+	bundleFiles, fileHashes, limitToFiles, err := b.collectFiles(ctx, span.Context(), rootPath, filePaths, changedFiles, includeFileContents, isSupported)
+	if err != nil || bundleFiles == nil {
+		return bundle, err
+	}
+
+	var bundleHash string
+	var missingFiles []string
+	if len(fileHashes) > 0 {
+		bundleHash, missingFiles, err = b.deepcodeClient.CreateBundle(span.Context(), fileHashes)
+	}
+
+	bundle = NewBundle(
+		b.deepcodeClient,
+		b.instrumentor,
+		b.errorReporter,
+		b.logger,
+		rootPath,
+		bundleHash,
+		bundleFiles,
+		limitToFiles,
+		missingFiles,
+	)
+	return bundle, err
+}
+
+// collectFiles reads the files accepted by isSupported and returns them by their encoded relative path, together with
+// their hashes and the subset of changed files the test should be limited to. If the context is cancelled, it returns
+// no files and no error.
+func (b *bundleManager) collectFiles(
+	ctx context.Context,
+	spanCtx context.Context,
+	rootPath string,
+	filePaths <-chan string,
+	changedFiles map[string]bool,
+	includeFileContents bool,
+	isSupported func(ctx context.Context, file string) (bool, error),
+) (bundleFiles map[string]deepcode.BundleFile, fileHashes map[string]string, limitToFiles []string, err error) {
+	fileHashes = make(map[string]string)
+	bundleFiles = make(map[string]deepcode.BundleFile)
 	noFiles := true
 	for absoluteFilePath := range filePaths {
 		noFiles = false
 		if ctx.Err() != nil {
-			return bundle, err // The cancellation error should be handled by the calling function
+			return nil, nil, nil, err // The cancellation error should be handled by the calling function
 		}
 		var supported bool
-		supported, err = b.IsSupported(span.Context(), absoluteFilePath)
+		supported, err = isSupported(spanCtx, absoluteFilePath)
 		if err != nil {
-			return bundle, err
+			return nil, nil, nil, err
 		}
 		if !supported {
 			continue
@@ -170,27 +235,9 @@ func (b *bundleManager) create(
 	}
 
 	if noFiles {
-		return bundle, NoFilesError{}
-	}
-
-	var bundleHash string
-	var missingFiles []string
-	if len(fileHashes) > 0 {
-		bundleHash, missingFiles, err = b.deepcodeClient.CreateBundle(span.Context(), fileHashes)
+		return nil, nil, nil, NoFilesError{}
 	}
-
-	bundle = NewBundle(
-		b.deepcodeClient,
-		b.instrumentor,
-		b.errorReporter,
-		b.logger,
-		rootPath,
-		bundleHash,
-		bundleFiles,
-		limitToFiles,
-		missingFiles,
-	)
-	return bundle, err
+	return bundleFiles, fileHashes, limitToFiles, nil
 }
 
 func (b *bundleManager) Upload(
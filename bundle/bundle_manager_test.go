@@ -354,6 +354,38 @@ func Test_Create(t *testing.T) {
 			assert.Contains(t, bundle.GetLimitToFiles(), expectedPath)
 		}
 	})
+
+	t.Run("unfiltered includes unsupported files", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockSpan := mocks.NewMockSpan(ctrl)
+		mockSpan.EXPECT().Context().AnyTimes()
+		mockSnykCodeClient := deepcodeMocks.NewMockDeepcodeClient(ctrl)
+		mockSnykCodeClient.EXPECT().GetFilters(gomock.Any()).Times(0)
+		mockSnykCodeClient.EXPECT().CreateBundle(gomock.Any(), map[string]string{
+			"bom.json": "3fb974902205514a4899b51cae63cc149a2a0fde3ee01aed488bc2406871a877",
+		}).Return("test-sbom-hash", []string{"bom.json"}, nil).Times(1)
+		mockInstrumentor := mocks.NewMockInstrumentor(ctrl)
+		mockInstrumentor.EXPECT().StartSpan(gomock.Any(), gomock.Any()).Return(mockSpan).AnyTimes()
+		mockInstrumentor.EXPECT().Finish(gomock.Any()).AnyTimes()
+		mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+		mockTracker := trackerMocks.NewMockTracker(ctrl)
+		mockTracker.EXPECT().Begin(gomock.Eq("Creating file bundle"), gomock.Eq("Checking and adding files for analysis")).Return()
+		mockTracker.EXPECT().End(gomock.Eq("")).Return()
+		mockTrackerFactory := trackerMocks.NewMockTrackerFactory(ctrl)
+		mockTrackerFactory.EXPECT().GenerateTracker().Return(mockTracker)
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "bom.json")
+		err := os.WriteFile(file, []byte(`{"bomFormat": "CycloneDX"}`), 0600)
+		require.NoError(t, err)
+
+		var bundleManager = bundle.NewBundleManager(mockSnykCodeClient, newLogger(t), mockInstrumentor, mockErrorReporter, mockTrackerFactory)
+		bundle, err := bundleManager.CreateEmptyUnfiltered(t.Context(), dir, sliceToChannel([]string{file}))
+		require.NoError(t, err)
+		assert.Equal(t, "test-sbom-hash", bundle.GetBundleHash())
+		assert.Contains(t, bundle.GetFiles(), "bom.json")
+		assert.Equal(t, []string{"bom.json"}, bundle.GetMissingFiles())
+	})
 }
 
 func Test_Upload(t *testing.T) {
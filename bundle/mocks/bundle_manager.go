@@ -80,3 +80,86 @@ func (mr *MockBundleManagerMockRecorder) Upload(ctx, requestId, originalBundle,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockBundleManager)(nil).Upload), ctx, requestId, originalBundle, files)
 }
+
+// MockUnfilteredBundleManager is a mock of UnfilteredBundleManager interface.
+type MockUnfilteredBundleManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnfilteredBundleManagerMockRecorder
+}
+
+// MockUnfilteredBundleManagerMockRecorder is the mock recorder for MockUnfilteredBundleManager.
+type MockUnfilteredBundleManagerMockRecorder struct {
+	mock *MockUnfilteredBundleManager
+}
+
+// NewMockUnfilteredBundleManager creates a new mock instance.
+func NewMockUnfilteredBundleManager(ctrl *gomock.Controller) *MockUnfilteredBundleManager {
+	mock := &MockUnfilteredBundleManager{ctrl: ctrl}
+	mock.recorder = &MockUnfilteredBundleManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnfilteredBundleManager) EXPECT() *MockUnfilteredBundleManagerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUnfilteredBundleManager) Create(ctx context.Context, requestId, rootPath string, filePaths <-chan string, changedFiles map[string]bool) (bundle.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, requestId, rootPath, filePaths, changedFiles)
+	ret0, _ := ret[0].(bundle.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUnfilteredBundleManagerMockRecorder) Create(ctx, requestId, rootPath, filePaths, changedFiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUnfilteredBundleManager)(nil).Create), ctx, requestId, rootPath, filePaths, changedFiles)
+}
+
+// CreateEmpty mocks base method.
+func (m *MockUnfilteredBundleManager) CreateEmpty(ctx context.Context, rootPath string, filePaths <-chan string, changedFiles map[string]bool) (bundle.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmpty", ctx, rootPath, filePaths, changedFiles)
+	ret0, _ := ret[0].(bundle.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmpty indicates an expected call of CreateEmpty.
+func (mr *MockUnfilteredBundleManagerMockRecorder) CreateEmpty(ctx, rootPath, filePaths, changedFiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmpty", reflect.TypeOf((*MockUnfilteredBundleManager)(nil).CreateEmpty), ctx, rootPath, filePaths, changedFiles)
+}
+
+// CreateEmptyUnfiltered mocks base method.
+func (m *MockUnfilteredBundleManager) CreateEmptyUnfiltered(ctx context.Context, rootPath string, filePaths <-chan string) (bundle.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmptyUnfiltered", ctx, rootPath, filePaths)
+	ret0, _ := ret[0].(bundle.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmptyUnfiltered indicates an expected call of CreateEmptyUnfiltered.
+func (mr *MockUnfilteredBundleManagerMockRecorder) CreateEmptyUnfiltered(ctx, rootPath, filePaths interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmptyUnfiltered", reflect.TypeOf((*MockUnfilteredBundleManager)(nil).CreateEmptyUnfiltered), ctx, rootPath, filePaths)
+}
+
+// Upload mocks base method.
+func (m *MockUnfilteredBundleManager) Upload(ctx context.Context, requestId string, originalBundle bundle.Bundle, files map[string]deepcode.BundleFile) (bundle.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, requestId, originalBundle, files)
+	ret0, _ := ret[0].(bundle.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockUnfilteredBundleManagerMockRecorder) Upload(ctx, requestId, originalBundle, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockUnfilteredBundleManager)(nil).Upload), ctx, requestId, originalBundle, files)
+}
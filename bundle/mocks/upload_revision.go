@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: upload_revision.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	bundle "github.com/snyk/code-client-go/bundle"
+	deepcode "github.com/snyk/code-client-go/internal/deepcode"
+)
+
+// MockUploadRevision is a mock of UploadRevision interface.
+type MockUploadRevision struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadRevisionMockRecorder
+}
+
+// MockUploadRevisionMockRecorder is the mock recorder for MockUploadRevision.
+type MockUploadRevisionMockRecorder struct {
+	mock *MockUploadRevision
+}
+
+// NewMockUploadRevision creates a new mock instance.
+func NewMockUploadRevision(ctrl *gomock.Controller) *MockUploadRevision {
+	mock := &MockUploadRevision{ctrl: ctrl}
+	mock.recorder = &MockUploadRevisionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadRevision) EXPECT() *MockUploadRevisionMockRecorder {
+	return m.recorder
+}
+
+// ClearFiles mocks base method.
+func (m *MockUploadRevision) ClearFiles() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearFiles")
+}
+
+// ClearFiles indicates an expected call of ClearFiles.
+func (mr *MockUploadRevisionMockRecorder) ClearFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFiles", reflect.TypeOf((*MockUploadRevision)(nil).ClearFiles))
+}
+
+// GetBundleHash mocks base method.
+func (m *MockUploadRevision) GetBundleHash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundleHash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetBundleHash indicates an expected call of GetBundleHash.
+func (mr *MockUploadRevisionMockRecorder) GetBundleHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundleHash", reflect.TypeOf((*MockUploadRevision)(nil).GetBundleHash))
+}
+
+// GetFiles mocks base method.
+func (m *MockUploadRevision) GetFiles() map[string]deepcode.BundleFile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiles")
+	ret0, _ := ret[0].(map[string]deepcode.BundleFile)
+	return ret0
+}
+
+// GetFiles indicates an expected call of GetFiles.
+func (mr *MockUploadRevisionMockRecorder) GetFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiles", reflect.TypeOf((*MockUploadRevision)(nil).GetFiles))
+}
+
+// GetLimitToFiles mocks base method.
+func (m *MockUploadRevision) GetLimitToFiles() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitToFiles")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetLimitToFiles indicates an expected call of GetLimitToFiles.
+func (mr *MockUploadRevisionMockRecorder) GetLimitToFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitToFiles", reflect.TypeOf((*MockUploadRevision)(nil).GetLimitToFiles))
+}
+
+// GetMissingFiles mocks base method.
+func (m *MockUploadRevision) GetMissingFiles() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissingFiles")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetMissingFiles indicates an expected call of GetMissingFiles.
+func (mr *MockUploadRevisionMockRecorder) GetMissingFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissingFiles", reflect.TypeOf((*MockUploadRevision)(nil).GetMissingFiles))
+}
+
+// GetRevisionId mocks base method.
+func (m *MockUploadRevision) GetRevisionId() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisionId")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRevisionId indicates an expected call of GetRevisionId.
+func (mr *MockUploadRevisionMockRecorder) GetRevisionId() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisionId", reflect.TypeOf((*MockUploadRevision)(nil).GetRevisionId))
+}
+
+// GetRootPath mocks base method.
+func (m *MockUploadRevision) GetRootPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRootPath indicates an expected call of GetRootPath.
+func (mr *MockUploadRevisionMockRecorder) GetRootPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootPath", reflect.TypeOf((*MockUploadRevision)(nil).GetRootPath))
+}
+
+// UploadBatch mocks base method.
+func (m *MockUploadRevision) UploadBatch(ctx context.Context, requestId string, batch *bundle.Batch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBatch", ctx, requestId, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadBatch indicates an expected call of UploadBatch.
+func (mr *MockUploadRevisionMockRecorder) UploadBatch(ctx, requestId, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBatch", reflect.TypeOf((*MockUploadRevision)(nil).UploadBatch), ctx, requestId, batch)
+}
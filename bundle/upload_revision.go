@@ -0,0 +1,238 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bundle
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rs/zerolog"
+
+	"github.com/snyk/code-client-go/config"
+	"github.com/snyk/code-client-go/internal/deepcode"
+	"github.com/snyk/code-client-go/internal/uploadrevision"
+	"github.com/snyk/code-client-go/observability"
+	"github.com/snyk/code-client-go/scan"
+)
+
+//go:generate go tool github.com/golang/mock/mockgen -destination=mocks/upload_revision.go -source=upload_revision.go -package mocks -aux_files=github.com/snyk/code-client-go/bundle=bundle.go
+
+// UploadRevision is a Bundle whose files are uploaded to an upload revision instead of a deepcode bundle. Its bundle
+// hash is the id of the revision.
+type UploadRevision interface {
+	Bundle
+	GetRevisionId() string
+}
+
+type uploadRevisionBundle struct {
+	client       uploadrevision.Client
+	logger       *zerolog.Logger
+	orgId        string
+	rootPath     string
+	revisionId   string
+	files        map[string]deepcode.BundleFile
+	missingFiles []string
+	limitToFiles []string
+}
+
+var _ UploadRevision = (*uploadRevisionBundle)(nil)
+
+func (b *uploadRevisionBundle) GetRevisionId() string {
+	return b.revisionId
+}
+
+func (b *uploadRevisionBundle) GetBundleHash() string {
+	return b.revisionId
+}
+
+func (b *uploadRevisionBundle) GetFiles() map[string]deepcode.BundleFile {
+	return b.files
+}
+
+func (b *uploadRevisionBundle) ClearFiles() {
+	b.files = make(map[string]deepcode.BundleFile)
+}
+
+func (b *uploadRevisionBundle) GetMissingFiles() []string {
+	return b.missingFiles
+}
+
+func (b *uploadRevisionBundle) GetLimitToFiles() []string {
+	return b.limitToFiles
+}
+
+func (b *uploadRevisionBundle) GetRootPath() string {
+	return b.rootPath
+}
+
+// UploadBatch uploads the files of the batch to the revision. The uploaded files are no longer reported as missing.
+func (b *uploadRevisionBundle) UploadBatch(ctx context.Context, requestId string, batch *Batch) error {
+	if !batch.hasContent() {
+		return nil
+	}
+
+	files := make(map[string][]byte, len(batch.documents))
+	for path, file := range batch.documents {
+		files[path] = []byte(file.Content)
+	}
+	err := b.client.UploadFiles(ctx, b.orgId, b.revisionId, files)
+	if err != nil {
+		return err
+	}
+
+	b.missingFiles = slices.DeleteFunc(b.missingFiles, func(path string) bool {
+		_, uploaded := batch.documents[path]
+		return uploaded
+	})
+	b.logger.Debug().Str("requestId", requestId).Int("missingFiles", len(b.missingFiles)).Msg("uploaded batch to upload revision")
+	return nil
+}
+
+type uploadRevisionManager struct {
+	*bundleManager
+	uploadRevisionClient uploadrevision.Client
+	config               config.Config
+}
+
+// NewUploadRevisionManager creates a BundleManager that uploads the files to an upload revision instead of a deepcode
+// bundle. The files are filtered with the same rules as for deepcode bundles.
+func NewUploadRevisionManager(
+	deepcodeClient deepcode.DeepcodeClient,
+	uploadRevisionClient uploadrevision.Client,
+	config config.Config,
+	logger *zerolog.Logger,
+	instrumentor observability.Instrumentor,
+	errorReporter observability.ErrorReporter,
+	trackerFactory scan.TrackerFactory,
+) *uploadRevisionManager {
+	return &uploadRevisionManager{
+		bundleManager:        NewBundleManager(deepcodeClient, logger, instrumentor, errorReporter, trackerFactory),
+		uploadRevisionClient: uploadRevisionClient,
+		config:               config,
+	}
+}
+
+var _ UnfilteredBundleManager = (*uploadRevisionManager)(nil)
+
+func (r *uploadRevisionManager) Create(
+	ctx context.Context,
+	requestId string,
+	rootPath string,
+	filePaths <-chan string,
+	changedFiles map[string]bool,
+) (bundle Bundle, err error) {
+	return r.create(ctx, rootPath, filePaths, changedFiles, true, r.IsSupported)
+}
+
+func (r *uploadRevisionManager) CreateEmpty(
+	ctx context.Context,
+	rootPath string,
+	filePaths <-chan string,
+	changedFiles map[string]bool,
+) (bundle Bundle, err error) {
+	return r.create(ctx, rootPath, filePaths, changedFiles, false, r.IsSupported)
+}
+
+func (r *uploadRevisionManager) CreateEmptyUnfiltered(
+	ctx context.Context,
+	rootPath string,
+	filePaths <-chan string,
+) (bundle Bundle, err error) {
+	return r.create(ctx, rootPath, filePaths, map[string]bool{}, false, allSupported)
+}
+
+func (r *uploadRevisionManager) create(
+	ctx context.Context,
+	rootPath string,
+	filePaths <-chan string,
+	changedFiles map[string]bool,
+	includeFileContents bool,
+	isSupported func(ctx context.Context, file string) (bool, error),
+) (bundle Bundle, err error) {
+	span := r.instrumentor.StartSpan(ctx, "code.createUploadRevision")
+	defer r.instrumentor.Finish(span)
+
+	tracker := r.trackerFactory.GenerateTracker()
+	tracker.Begin("Creating upload revision", "Checking and adding files for analysis")
+	defer tracker.End("")
+
+	bundleFiles, _, limitToFiles, err := r.collectFiles(ctx, span.Context(), rootPath, filePaths, changedFiles, includeFileContents, isSupported)
+	if err != nil || bundleFiles == nil {
+		return bundle, err
+	}
+
+	var revisionId string
+	var missingFiles []string
+	if len(bundleFiles) > 0 {
+		revisionId, err = r.uploadRevisionClient.CreateRevision(ctx, r.config.Organization())
+		for path := range bundleFiles {
+			missingFiles = append(missingFiles, path)
+		}
+		slices.Sort(missingFiles)
+	}
+
+	bundle = &uploadRevisionBundle{
+		client:       r.uploadRevisionClient,
+		logger:       r.logger,
+		orgId:        r.config.Organization(),
+		rootPath:     rootPath,
+		revisionId:   revisionId,
+		files:        bundleFiles,
+		missingFiles: missingFiles,
+		limitToFiles: limitToFiles,
+	}
+	return bundle, err
+}
+
+// Upload uploads the files in batches and seals the revision once all of them have been uploaded.
+func (r *uploadRevisionManager) Upload(
+	ctx context.Context,
+	requestId string,
+	bundle Bundle,
+	files map[string]deepcode.BundleFile,
+) (Bundle, error) {
+	method := "code.UploadRevision"
+	s := r.instrumentor.StartSpan(ctx, method)
+	defer r.instrumentor.Finish(s)
+
+	if bundle.GetBundleHash() == "" {
+		return bundle, nil
+	}
+
+	tracker := r.trackerFactory.GenerateTracker()
+	tracker.Begin("Snyk Code analysis for "+bundle.GetRootPath(), "Uploading files...")
+	defer tracker.End("Upload done.")
+
+	for _, batch := range r.groupInBatches(s.Context(), bundle, files) {
+		if err := ctx.Err(); err != nil {
+			return bundle, err
+		}
+		r.enrichBatchWithFileContent(batch, bundle.GetRootPath())
+		err := bundle.UploadBatch(ctx, requestId, batch)
+		if err != nil {
+			return bundle, err
+		}
+	}
+
+	err := r.uploadRevisionClient.SealRevision(ctx, r.config.Organization(), bundle.GetBundleHash())
+	if err != nil {
+		return bundle, err
+	}
+
+	bundle.ClearFiles()
+	return bundle, nil
+}
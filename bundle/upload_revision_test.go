@@ -0,0 +1,139 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bundle_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/snyk/code-client-go/bundle"
+	confMocks "github.com/snyk/code-client-go/config/mocks"
+	"github.com/snyk/code-client-go/internal/deepcode"
+	deepcodeMocks "github.com/snyk/code-client-go/internal/deepcode/mocks"
+	uploadRevisionMocks "github.com/snyk/code-client-go/internal/uploadrevision/mocks"
+	"github.com/snyk/code-client-go/observability/mocks"
+	trackerMocks "github.com/snyk/code-client-go/scan/mocks"
+)
+
+func setupUploadRevisionManager(t *testing.T) (*uploadRevisionMocks.MockClient, bundle.BundleManager) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSpan := mocks.NewMockSpan(ctrl)
+	mockSpan.EXPECT().Context().AnyTimes()
+	mockInstrumentor := mocks.NewMockInstrumentor(ctrl)
+	mockInstrumentor.EXPECT().StartSpan(gomock.Any(), gomock.Any()).Return(mockSpan).AnyTimes()
+	mockInstrumentor.EXPECT().Finish(gomock.Any()).AnyTimes()
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	mockTracker := trackerMocks.NewMockTracker(ctrl)
+	mockTracker.EXPECT().Begin(gomock.Any(), gomock.Any()).AnyTimes()
+	mockTracker.EXPECT().End(gomock.Any()).AnyTimes()
+	mockTrackerFactory := trackerMocks.NewMockTrackerFactory(ctrl)
+	mockTrackerFactory.EXPECT().GenerateTracker().Return(mockTracker).AnyTimes()
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().Return("test-org").AnyTimes()
+	mockDeepcodeClient := deepcodeMocks.NewMockDeepcodeClient(ctrl)
+	mockDeepcodeClient.EXPECT().GetFilters(gomock.Any()).Return(deepcode.FiltersResponse{
+		ConfigFiles: []string{},
+		Extensions:  []string{".java"},
+	}, nil).AnyTimes()
+	mockUploadRevisionClient := uploadRevisionMocks.NewMockClient(ctrl)
+
+	manager := bundle.NewUploadRevisionManager(
+		mockDeepcodeClient,
+		mockUploadRevisionClient,
+		mockConfig,
+		newLogger(t),
+		mockInstrumentor,
+		mockErrorReporter,
+		mockTrackerFactory,
+	)
+	return mockUploadRevisionClient, manager
+}
+
+func Test_UploadRevisionManager_CreateAndUpload(t *testing.T) {
+	mockUploadRevisionClient, manager := setupUploadRevisionManager(t)
+
+	dir := t.TempDir()
+	javaFile := filepath.Join(dir, "Main.java")
+	require.NoError(t, os.WriteFile(javaFile, []byte("class Main {}"), 0600))
+	unsupportedFile := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(unsupportedFile, []byte("not uploaded"), 0600))
+
+	gomock.InOrder(
+		mockUploadRevisionClient.EXPECT().CreateRevision(gomock.Any(), "test-org").Return("revision-id", nil),
+		mockUploadRevisionClient.EXPECT().UploadFiles(gomock.Any(), "test-org", "revision-id", map[string][]byte{
+			"Main.java": []byte("class Main {}"),
+		}).Return(nil),
+		mockUploadRevisionClient.EXPECT().SealRevision(gomock.Any(), "test-org", "revision-id").Return(nil),
+	)
+
+	createdBundle, err := manager.CreateEmpty(t.Context(), dir, sliceToChannel([]string{javaFile, unsupportedFile}), map[string]bool{javaFile: true})
+	require.NoError(t, err)
+	assert.Equal(t, "revision-id", createdBundle.GetBundleHash())
+	assert.Equal(t, []string{"Main.java"}, createdBundle.GetMissingFiles())
+	assert.Equal(t, []string{"Main.java"}, createdBundle.GetLimitToFiles())
+
+	uploadedBundle, err := manager.Upload(t.Context(), "request-id", createdBundle, createdBundle.GetFiles())
+	require.NoError(t, err)
+
+	revision, ok := uploadedBundle.(bundle.UploadRevision)
+	require.True(t, ok)
+	assert.Equal(t, "revision-id", revision.GetRevisionId())
+	assert.Empty(t, uploadedBundle.GetMissingFiles())
+	assert.Empty(t, uploadedBundle.GetFiles())
+}
+
+func Test_UploadRevisionManager_NoSupportedFiles(t *testing.T) {
+	mockUploadRevisionClient, manager := setupUploadRevisionManager(t)
+	mockUploadRevisionClient.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Times(0)
+
+	dir := t.TempDir()
+	unsupportedFile := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(unsupportedFile, []byte("not uploaded"), 0600))
+
+	createdBundle, err := manager.CreateEmpty(t.Context(), dir, sliceToChannel([]string{unsupportedFile}), map[string]bool{})
+	require.NoError(t, err)
+	assert.Empty(t, createdBundle.GetBundleHash())
+
+	uploadedBundle, err := manager.Upload(t.Context(), "request-id", createdBundle, createdBundle.GetFiles())
+	require.NoError(t, err)
+	assert.Empty(t, uploadedBundle.GetBundleHash())
+}
+
+func Test_UploadRevisionManager_UploadFailed(t *testing.T) {
+	mockUploadRevisionClient, manager := setupUploadRevisionManager(t)
+
+	dir := t.TempDir()
+	javaFile := filepath.Join(dir, "Main.java")
+	require.NoError(t, os.WriteFile(javaFile, []byte("class Main {}"), 0600))
+
+	mockUploadRevisionClient.EXPECT().CreateRevision(gomock.Any(), "test-org").Return("revision-id", nil)
+	mockUploadRevisionClient.EXPECT().UploadFiles(gomock.Any(), "test-org", "revision-id", gomock.Any()).Return(errors.New("upload failed"))
+	mockUploadRevisionClient.EXPECT().SealRevision(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	createdBundle, err := manager.CreateEmpty(t.Context(), dir, sliceToChannel([]string{javaFile}), map[string]bool{})
+	require.NoError(t, err)
+
+	_, err = manager.Upload(t.Context(), "request-id", createdBundle, createdBundle.GetFiles())
+	assert.ErrorContains(t, err, "upload failed")
+}
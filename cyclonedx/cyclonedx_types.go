@@ -0,0 +1,94 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//nolint:revive,tagliatelle // These are all CycloneDX documented types that need to match the exact JSON format.
+package cyclonedx
+
+// Document matches the subset of the spec in https://cyclonedx.org/docs/1.6/json/ that is returned by the test service.
+type Document struct {
+	BomFormat       string          `json:"bomFormat"`
+	SpecVersion     string          `json:"specVersion"`
+	SerialNumber    string          `json:"serialNumber,omitempty"`
+	Version         int             `json:"version"`
+	Metadata        *Metadata       `json:"metadata,omitempty"`
+	Components      []Component     `json:"components,omitempty"`
+	Dependencies    []Dependency    `json:"dependencies,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type Metadata struct {
+	Timestamp string     `json:"timestamp,omitempty"`
+	Component *Component `json:"component,omitempty"`
+}
+
+type Component struct {
+	BomRef     string     `json:"bom-ref,omitempty"`
+	Type       string     `json:"type"`
+	Group      string     `json:"group,omitempty"`
+	Name       string     `json:"name"`
+	Version    string     `json:"version,omitempty"`
+	Purl       string     `json:"purl,omitempty"`
+	Licenses   []License  `json:"licenses,omitempty"`
+	Properties []Property `json:"properties,omitempty"`
+}
+
+type License struct {
+	License    *LicenseChoice `json:"license,omitempty"`
+	Expression string         `json:"expression,omitempty"`
+}
+
+type LicenseChoice struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+type Vulnerability struct {
+	BomRef      string     `json:"bom-ref,omitempty"`
+	Id          string     `json:"id"`
+	Source      *Source    `json:"source,omitempty"`
+	Ratings     []Rating   `json:"ratings,omitempty"`
+	Cwes        []int      `json:"cwes,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Affects     []Affect   `json:"affects,omitempty"`
+	Properties  []Property `json:"properties,omitempty"`
+}
+
+type Source struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+}
+
+type Rating struct {
+	Source   *Source `json:"source,omitempty"`
+	Score    float64 `json:"score,omitempty"`
+	Severity string  `json:"severity,omitempty"`
+	Method   string  `json:"method,omitempty"`
+	Vector   string  `json:"vector,omitempty"`
+}
+
+type Affect struct {
+	Ref string `json:"ref"`
+}
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -203,3 +204,22 @@ func EncodeIfNeeded(method string, requestBody []byte, needsEncoding bool) (*byt
 func mustBeEncoded(method string, needsEncoding bool) bool {
 	return needsEncoding && (method == http.MethodPost || method == http.MethodPut)
 }
+
+// RetryAfter returns the wait time requested by the server via the Retry-After header of the response, or zero
+// if the header is missing or invalid. Both the delay-seconds and the HTTP-date format are supported.
+func RetryAfter(response *http.Response) time.Duration {
+	if response == nil {
+		return 0
+	}
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
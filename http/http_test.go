@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rs/zerolog"
@@ -150,6 +151,30 @@ func TestSnykCodeBackendService_DoCall_shouldFail(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRetryAfter(t *testing.T) {
+	newResponse := func(retryAfter string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{retryAfter}}}
+	}
+
+	t.Run("delay in seconds", func(t *testing.T) {
+		assert.Equal(t, 5*time.Second, codeClientHTTP.RetryAfter(newResponse("5")))
+	})
+
+	t.Run("http date", func(t *testing.T) {
+		date := time.Now().Add(1 * time.Minute).UTC().Format(http.TimeFormat)
+		retryAfter := codeClientHTTP.RetryAfter(newResponse(date))
+		assert.Greater(t, retryAfter, 50*time.Second)
+		assert.LessOrEqual(t, retryAfter, 1*time.Minute)
+	})
+
+	t.Run("missing or invalid header", func(t *testing.T) {
+		assert.Zero(t, codeClientHTTP.RetryAfter(&http.Response{}))
+		assert.Zero(t, codeClientHTTP.RetryAfter(newResponse("soon")))
+		assert.Zero(t, codeClientHTTP.RetryAfter(newResponse("-3")))
+		assert.Zero(t, codeClientHTTP.RetryAfter(nil))
+	})
+}
+
 func newLogger(t *testing.T) *zerolog.Logger {
 	t.Helper()
 	logger := zerolog.New(zerolog.NewTestWriter(t))
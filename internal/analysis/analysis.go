@@ -25,7 +25,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,9 +35,11 @@ import (
 
 	"github.com/snyk/code-client-go/bundle"
 	"github.com/snyk/code-client-go/config"
+	"github.com/snyk/code-client-go/cyclonedx"
 	codeClientHTTP "github.com/snyk/code-client-go/http"
 	testApi "github.com/snyk/code-client-go/internal/api/test/2025-04-07"
 	testModels "github.com/snyk/code-client-go/internal/api/test/2025-04-07/models"
+	"github.com/snyk/code-client-go/internal/util"
 	"github.com/snyk/code-client-go/observability"
 	"github.com/snyk/code-client-go/sarif"
 	"github.com/snyk/code-client-go/scan"
@@ -49,7 +51,16 @@ import (
 type AnalysisOrchestrator interface {
 	RunTest(ctx context.Context, orgId string, b bundle.Bundle, target scan.Target, reportingOptions AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error)
 	RunTestRemote(ctx context.Context, orgId string, reportingOptions AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error)
-	RunLegacyTest(ctx context.Context, bundleHash string, shardKey string, limitToFiles []string, severity int) (*sarif.SarifResponse, scan.LegacyScanStatus, error)
+	RunLegacyTest(ctx context.Context, bundleHash string, shardKey string, limitToFiles []string, severity int, prioritized bool) (*sarif.SarifResponse, scan.LegacyScanStatus, error)
+	ResumeTest(ctx context.Context, orgId string, testId string) (*sarif.SarifResponse, *scan.ResultMetaData, error)
+	CreateTest(ctx context.Context, orgId string, b bundle.Bundle, target scan.Target, reportingOptions AnalysisConfig) (string, error)
+	RunTestGitUrlCoordinates(ctx context.Context, orgId string, reportingOptions AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error)
+	RunTestDiff(ctx context.Context, orgId string, reportingOptions AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error)
+	RunTestWorkspace(ctx context.Context, orgId string, reportingOptions AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error)
+	RunTestSbom(ctx context.Context, orgId string, sourceBundle bundle.Bundle, sbomBundle bundle.Bundle, target scan.Target, sbomFilePath string, reportingOptions AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error)
+	CreateTestRemote(ctx context.Context, orgId string, reportingOptions AnalysisConfig) (string, error)
+	GetTestStatus(ctx context.Context, orgId string, testId string) (scan.TestStatus, error)
+	GetTestConfiguration(ctx context.Context, orgId string, testId string) (*scan.TestConfiguration, error)
 }
 
 type AnalysisConfig struct {
@@ -60,16 +71,46 @@ type AnalysisConfig struct {
 	TargetReference *string
 	ProjectId       *uuid.UUID
 	CommitId        *string
+	IntegrationId   *uuid.UUID
+	RepoUrl         *string
+	Revision        *string
+	TargetId        *uuid.UUID
+	BaseVersion     *string
+	HeadVersion     *string
+	WorkspaceId     *uuid.UUID
+	// Initiator is what triggered the test. If it is not set, it is derived from the scan.ScanSource of the context.
+	Initiator *string
+	// Label and Labels are arbitrary values attached to the test, e.g. the id of the pipeline that ran it.
+	Label  *string
+	Labels *map[string]string
+	// Origin is the source control management system or platform the tested code originates from.
+	Origin         *string
+	ExclusionGlobs *[]string
+	Scanners       *[]string
+	// StreamHandler receives the results of the findings document one at a time while it is downloaded. The
+	// returned SarifResponse then contains the runs without their results.
+	StreamHandler *sarif.StreamHandler
+	// OnTestCreated is called with the test id as soon as the test has been created, before polling for results.
+	// The id can be persisted and passed to ResumeTest to continue an interrupted analysis.
+	OnTestCreated func(testId string)
 }
 
 type analysisOrchestrator struct {
-	httpClient     codeClientHTTP.HTTPClient
-	instrumentor   observability.Instrumentor
-	errorReporter  observability.ErrorReporter
-	logger         *zerolog.Logger
-	trackerFactory scan.TrackerFactory
-	config         config.Config
-	testType       testModels.ResultType
+	httpClient      codeClientHTTP.HTTPClient
+	instrumentor    observability.Instrumentor
+	errorReporter   observability.ErrorReporter
+	logger          *zerolog.Logger
+	trackerFactory  scan.TrackerFactory
+	config          config.Config
+	testType        testModels.ResultType
+	pollingStrategy scan.PollingStrategy
+	summaryOnly     bool
+	apiVersion      string
+	// legacyRequestContext is sent as the analysis context of legacy analyses.
+	legacyRequestContext LegacyRequestContext
+	// apiVersionMutex guards apiVersion, which changes when the orchestrator falls back to an older version.
+	apiVersionMutex     sync.Mutex
+	deprecationWarnings sync.Map
 }
 
 var _ AnalysisOrchestrator = (*analysisOrchestrator)(nil)
@@ -106,6 +147,20 @@ func WithResultType(t testModels.ResultType) func(*analysisOrchestrator) {
 	}
 }
 
+func WithPollingStrategy(strategy scan.PollingStrategy) func(*analysisOrchestrator) {
+	return func(a *analysisOrchestrator) {
+		a.pollingStrategy = strategy
+	}
+}
+
+// WithSummaryOnly skips downloading the findings documents once a test has completed. The analysis then only returns
+// the result metadata, e.g. the severity counts, and a nil SarifResponse.
+func WithSummaryOnly(summaryOnly bool) func(*analysisOrchestrator) {
+	return func(a *analysisOrchestrator) {
+		a.summaryOnly = summaryOnly
+	}
+}
+
 func NewAnalysisOrchestrator(
 	config config.Config,
 	httpClient codeClientHTTP.HTTPClient,
@@ -114,13 +169,15 @@ func NewAnalysisOrchestrator(
 	nopLogger := zerolog.Nop()
 
 	a := &analysisOrchestrator{
-		httpClient:     httpClient,
-		config:         config,
-		instrumentor:   observability.NewInstrumentor(),
-		trackerFactory: scan.NewNoopTrackerFactory(),
-		errorReporter:  observability.NewErrorReporter(&nopLogger),
-		logger:         &nopLogger,
-		testType:       testModels.CodeSecurityCodeQuality,
+		httpClient:      httpClient,
+		config:          config,
+		instrumentor:    observability.NewInstrumentor(),
+		trackerFactory:  scan.NewNoopTrackerFactory(),
+		errorReporter:   observability.NewErrorReporter(&nopLogger),
+		logger:          &nopLogger,
+		testType:        testModels.CodeSecurityCodeQuality,
+		pollingStrategy: scan.NewDefaultPollingStrategy(),
+		apiVersion:      ApiVersion20250407,
 	}
 
 	for _, option := range options {
@@ -130,7 +187,7 @@ func NewAnalysisOrchestrator(
 	return a
 }
 
-func (a *analysisOrchestrator) retrieveFindings(ctx context.Context, scanJobId uuid.UUID, findingsUrl string) (*sarif.SarifResponse, error) {
+func (a *analysisOrchestrator) retrieveFindings(ctx context.Context, scanJobId uuid.UUID, findingsUrl string, streamHandler *sarif.StreamHandler) (*sarif.SarifResponse, error) {
 	method := "analysis.retrieveFindings"
 	logger := a.logger.With().Str("method", method).Logger()
 	logger.Debug().Str("scanJobId", scanJobId.String()).Msg("retrieving findings from URL for scan job")
@@ -138,89 +195,179 @@ func (a *analysisOrchestrator) retrieveFindings(ctx context.Context, scanJobId u
 	if findingsUrl == "" {
 		return nil, errors.New("do not have a findings URL")
 	}
-	req, err := http.NewRequest(http.MethodGet, findingsUrl, nil)
+
+	var sarifDocument sarif.SarifDocument
+	var err error
+	if streamHandler != nil {
+		err = a.streamDocument(ctx, findingsUrl, func(body io.Reader) error {
+			document, decodeErr := sarif.DecodeStream(body, *streamHandler)
+			if decodeErr != nil {
+				return decodeErr
+			}
+			sarifDocument = *document
+			return nil
+		})
+	} else {
+		err = a.retrieveDocument(ctx, findingsUrl, &sarifDocument)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	return &sarif.SarifResponse{
+		Type:     "sarif",
+		Progress: 1,
+		Status:   "COMPLETE",
+		Sarif:    sarifDocument,
+	}, nil
+}
+
+// retrieveComponentDocuments downloads the findings documents of all successful components. The document of the
+// component the findings were retrieved from is reused instead of being downloaded again.
+func (a *analysisOrchestrator) retrieveComponentDocuments(ctx context.Context, resultMetaData *scan.ResultMetaData, findings *sarif.SarifResponse) error {
+	for i := range resultMetaData.Components {
+		component := &resultMetaData.Components[i]
+		if !component.Success || component.FindingsUrl == "" {
+			continue
+		}
+
+		if component.FindingsUrl == resultMetaData.FindingsUrl {
+			component.Sarif = &findings.Sarif
+			continue
+		}
+
+		var err error
+		switch component.FindingsDocumentType {
+		case scan.FindingsDocumentTypeSarif:
+			component.Sarif = &sarif.SarifDocument{}
+			err = a.retrieveDocument(ctx, component.FindingsUrl, component.Sarif)
+		case scan.FindingsDocumentTypeCycloneDX:
+			component.CycloneDX = &cyclonedx.Document{}
+			err = a.retrieveDocument(ctx, component.FindingsUrl, component.CycloneDX)
+		default:
+			a.logger.Debug().Msgf("skipping findings document of unknown type %q", component.FindingsDocumentType)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to retrieve findings of %s component: %w", component.Type, err)
+		}
+	}
+	return nil
+}
+
+func (a *analysisOrchestrator) retrieveDocument(ctx context.Context, documentUrl string, document any) error {
+	return a.streamDocument(ctx, documentUrl, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(document)
+	})
+}
+
+// maxErrorBodySize limits how much of an unsuccessful response is read to decode its error document.
+const maxErrorBodySize = 1 << 20
+
+// streamDocument downloads a findings document and passes the body to decode without buffering it.
+func (a *analysisOrchestrator) streamDocument(ctx context.Context, documentUrl string, decode func(body io.Reader) error) error {
+	req, err := http.NewRequest(http.MethodGet, documentUrl, nil)
+	if err != nil {
+		return err
+	}
 	req = req.WithContext(ctx)
 
 	rsp, err := a.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() { _ = rsp.Body.Close() }()
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	if err != nil {
-		return nil, err
-	}
 
 	if rsp.StatusCode != http.StatusOK {
-		return nil, errors.New("failed to retrieve findings from findings URL")
+		errorBody, _ := io.ReadAll(io.LimitReader(rsp.Body, maxErrorBodySize))
+		return fmt.Errorf("failed to retrieve findings from findings URL: %w", testApi.NewErrorFromResponse(rsp.StatusCode, errorBody))
 	}
 
-	var sarifDocument sarif.SarifDocument
-	err = json.Unmarshal(bodyBytes, &sarifDocument)
+	return decode(rsp.Body)
+}
+
+func (a *analysisOrchestrator) host(isHidden bool) string {
+	return util.RestApiUrl(a.config.SnykApi(), isHidden)
+}
+
+func (a *analysisOrchestrator) newTestClient() (*testApi.Client, error) {
+	return testApi.NewClient(a.host(true), testApi.WithHTTPClient(a.httpClient))
+}
+
+// initiatorsByScanSource maps the source of a scan to the initiator of its test. There is no initiator for scans of an
+// LLM, so their tests are created without one.
+var initiatorsByScanSource = map[scan.ScanSource]testModels.OutputConfigInitiator{
+	scan.IDE: testModels.IdeTest,
+	scan.CLI: testModels.CliTest,
+}
+
+// withContextInitiator sets the initiator of the test from the scan.ScanSource of the context, unless it has been set
+// explicitly.
+func withContextInitiator(ctx context.Context, body *testApi.CreateTestApplicationVndAPIPlusJSONRequestBody) {
+	output := body.Data.Attributes.Configuration.Output
+	if output != nil && output.Initiator != nil {
+		return
+	}
+	source, ok := scan.ScanSourceFromContext(ctx)
+	if !ok {
+		return
+	}
+	if initiator, found := initiatorsByScanSource[source]; found {
+		testApi.WithInitiator((*string)(&initiator))(body)
+	}
+}
+
+func (a *analysisOrchestrator) createTest(ctx context.Context, client *testApi.Client, orgUuid uuid.UUID, body *testApi.CreateTestApplicationVndAPIPlusJSONRequestBody) (openapi_types.UUID, error) {
+	params := testApi.CreateTestParams{Version: testApi.ApiVersion}
+	withContextInitiator(ctx, body)
+
+	resp, err := client.CreateTestWithApplicationVndAPIPlusJSONBody(ctx, orgUuid, &params, *body)
 	if err != nil {
-		return nil, err
+		return uuid.Nil, err
 	}
 
-	return &sarif.SarifResponse{
-		Type:     "sarif",
-		Progress: 1,
-		Status:   "COMPLETE",
-		Sarif:    sarifDocument,
-	}, nil
-}
+	parsedResponse, err := testApi.ParseCreateTestResponse(resp)
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			a.logger.Err(closeErr).Msg("failed to close response body")
+		}
+	}()
+	if err != nil {
+		a.logger.Debug().Msg(err.Error())
+		return uuid.Nil, err
+	}
+	if err = a.checkApiVersionHeaders(resp, testApi.ApiVersion); err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %w", errTestNotCreated, err)
+	}
 
-func (a *analysisOrchestrator) host(isHidden bool) string {
-	apiUrl := strings.TrimRight(a.config.SnykApi(), "/")
-	// Temporary Workaround because intellij currently adds a /v1 suffix to the EndpointAPI
-	apiUrl = strings.Replace(apiUrl, "/v1", "", 1)
-	path := "rest"
-	if isHidden {
-		path = "hidden"
+	if parsedResponse.StatusCode() != http.StatusCreated || parsedResponse.ApplicationvndApiJSON201 == nil {
+		return uuid.Nil, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
 	}
-	return fmt.Sprintf("%s/%s", apiUrl, path)
+	return parsedResponse.ApplicationvndApiJSON201.Data.Id, nil
 }
 
-func (a *analysisOrchestrator) createTestAndGetResults(ctx context.Context, orgId string, body *testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, progressString string) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+func (a *analysisOrchestrator) createTestAndGetResults(ctx context.Context, orgId string, body *testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, progressString string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
 	tracker := a.trackerFactory.GenerateTracker()
 	tracker.Begin(progressString, "Retrieving results...")
 
 	innerFunction := func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
-		params := testApi.CreateTestParams{Version: testApi.ApiVersion}
 		orgUuid := uuid.MustParse(orgId)
-		host := a.host(true)
 
-		client, err := testApi.NewClient(host, testApi.WithHTTPClient(a.httpClient))
+		client, err := a.newTestClient()
 		if err != nil {
 			return nil, nil, err
 		}
 
-		// create test
-		resp, err := client.CreateTestWithApplicationVndAPIPlusJSONBody(ctx, orgUuid, &params, *body)
+		testId, err := a.createTest(ctx, client, orgUuid, body)
 		if err != nil {
 			return nil, nil, err
 		}
-
-		parsedResponse, err := testApi.ParseCreateTestResponse(resp)
-		defer func() {
-			closeErr := resp.Body.Close()
-			if closeErr != nil {
-				a.logger.Err(closeErr).Msg("failed to close response body")
-			}
-		}()
-		if err != nil {
-			a.logger.Debug().Msg(err.Error())
-			return nil, nil, err
+		if cfg.OnTestCreated != nil {
+			cfg.OnTestCreated(testId.String())
 		}
 
-		switch parsedResponse.StatusCode() {
-		case http.StatusCreated:
-			// poll results
-			return a.pollTestForFindings(ctx, client, orgUuid, parsedResponse.ApplicationvndApiJSON201.Data.Id)
-		}
-		return nil, nil, nil
+		// poll results
+		return a.pollTestForFindings(ctx, client, orgUuid, testId, cfg.StreamHandler)
 	}
 
 	result, metadata, err := innerFunction()
@@ -233,7 +380,29 @@ func (a *analysisOrchestrator) createTestAndGetResults(ctx context.Context, orgI
 	return result, metadata, err
 }
 
-func (a *analysisOrchestrator) RunTest(ctx context.Context, orgId string, b bundle.Bundle, target scan.Target, reportingConfig AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+// createTestOnly creates the test without waiting for its results and returns the test id.
+func (a *analysisOrchestrator) createTestOnly(ctx context.Context, orgId string, body *testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, onTestCreated func(testId string)) (string, error) {
+	orgUuid, err := uuid.Parse(orgId)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid orgId")
+	}
+
+	client, err := a.newTestClient()
+	if err != nil {
+		return "", err
+	}
+
+	testId, err := a.createTest(ctx, client, orgUuid, body)
+	if err != nil {
+		return "", err
+	}
+	if onTestCreated != nil {
+		onTestCreated(testId.String())
+	}
+	return testId.String(), nil
+}
+
+func (a *analysisOrchestrator) newBundleTestBody(b bundle.Bundle, target scan.Target, reportingConfig AnalysisConfig) *testApi.CreateTestApplicationVndAPIPlusJSONRequestBody {
 	var commitId *string = nil
 	var repoUrl *string = nil
 	var branchName *string = nil
@@ -252,69 +421,549 @@ func (a *analysisOrchestrator) RunTest(ctx context.Context, orgId string, b bund
 		}
 	}
 
-	body := testApi.NewCreateTestApplicationBody(
-		testApi.WithInputBundle(b.GetBundleHash(), target.GetPath(), repoUrl, b.GetLimitToFiles(), commitId, branchName),
+	input := testApi.WithInputBundle(b.GetBundleHash(), target.GetPath(), repoUrl, b.GetLimitToFiles(), commitId, branchName)
+	var limitToFiles []string
+	if revision, ok := b.(bundle.UploadRevision); ok {
+		// the input of an upload revision has no metadata for the files, they are limited in the scan configuration
+		input = testApi.WithInputUploadRevision(revision.GetRevisionId(), target.GetPath(), repoUrl)
+		limitToFiles = revision.GetLimitToFiles()
+	}
+
+	return testApi.NewCreateTestApplicationBody(
+		input,
+		testApi.WithLimitTestToFiles(limitToFiles),
 		testApi.WithScanType(a.testType),
+		testApi.WithExclusionGlobs(reportingConfig.ExclusionGlobs),
+		testApi.WithScanners(reportingConfig.Scanners),
 		testApi.WithProjectName(reportingConfig.ProjectName),
 		testApi.WithProjectTags(reportingConfig.ProjectTags),
 		testApi.WithTargetName(reportingConfig.TargetName),
 		testApi.WithTargetReference(reportingConfig.TargetReference),
+		testApi.WithInitiator(reportingConfig.Initiator),
+		testApi.WithLabel(reportingConfig.Label),
+		testApi.WithLabels(reportingConfig.Labels),
+		testApi.WithOrigin(reportingConfig.Origin),
 		testApi.WithReporting(&reportingConfig.Report),
 	)
-
-	return a.createTestAndGetResults(ctx, orgId, body, "Snyk Code analysis for "+target.GetPath())
 }
 
-func (a *analysisOrchestrator) RunTestRemote(ctx context.Context, orgId string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+func (a *analysisOrchestrator) newRemoteTestBody(cfg AnalysisConfig) (*testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, error) {
 	if cfg.ProjectId == nil || cfg.CommitId == nil {
-		return nil, nil, errors.New("projectId and commitId are required")
+		return nil, errors.New("projectId and commitId are required")
 	}
 
 	legacyScmProject := testApi.NewTestInputLegacyScmProject(*cfg.ProjectId, *cfg.CommitId)
-	body := testApi.NewCreateTestApplicationBody(
+	return testApi.NewCreateTestApplicationBody(
 		testApi.WithInputLegacyScmProject(legacyScmProject),
 		testApi.WithReporting(&cfg.Report),
 		testApi.WithScanType(a.testType),
+		testApi.WithExclusionGlobs(cfg.ExclusionGlobs),
+		testApi.WithScanners(cfg.Scanners),
 		testApi.WithProjectId(*cfg.ProjectId),
+		testApi.WithInitiator(cfg.Initiator),
+		testApi.WithLabel(cfg.Label),
+		testApi.WithLabels(cfg.Labels),
+		testApi.WithOrigin(cfg.Origin),
+	), nil
+}
+
+func (a *analysisOrchestrator) newGitUrlCoordinatesTestBody(cfg AnalysisConfig) (*testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, error) {
+	if cfg.IntegrationId == nil || cfg.RepoUrl == nil || cfg.Revision == nil {
+		return nil, errors.New("integrationId, repoUrl and revision are required")
+	}
+
+	repoUrl, err := util.SanitiseCredentials(*cfg.RepoUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	coordinates := testApi.NewTestInputGitUrlCoordinates(*cfg.IntegrationId, repoUrl, *cfg.Revision)
+	return testApi.NewCreateTestApplicationBody(
+		testApi.WithInputGitUrlCoordinates(coordinates),
+		testApi.WithScanType(a.testType),
+		testApi.WithExclusionGlobs(cfg.ExclusionGlobs),
+		testApi.WithScanners(cfg.Scanners),
+		testApi.WithProjectName(cfg.ProjectName),
+		testApi.WithProjectTags(cfg.ProjectTags),
+		testApi.WithTargetName(cfg.TargetName),
+		testApi.WithTargetReference(cfg.TargetReference),
+		testApi.WithInitiator(cfg.Initiator),
+		testApi.WithLabel(cfg.Label),
+		testApi.WithLabels(cfg.Labels),
+		testApi.WithOrigin(cfg.Origin),
+		testApi.WithReporting(&cfg.Report),
+	), nil
+}
+
+func (a *analysisOrchestrator) newDiffTestBody(cfg AnalysisConfig) (*testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, error) {
+	if cfg.TargetId == nil || cfg.BaseVersion == nil || cfg.HeadVersion == nil {
+		return nil, errors.New("targetId, baseVersion and headVersion are required")
+	}
+
+	diffTarget := testApi.NewTestInputDiffTarget(*cfg.TargetId, *cfg.BaseVersion, *cfg.HeadVersion)
+	return testApi.NewCreateTestApplicationBody(
+		testApi.WithInputDiffTarget(diffTarget),
+		testApi.WithScanType(a.testType),
+		testApi.WithExclusionGlobs(cfg.ExclusionGlobs),
+		testApi.WithScanners(cfg.Scanners),
+		testApi.WithInitiator(cfg.Initiator),
+		testApi.WithLabel(cfg.Label),
+		testApi.WithLabels(cfg.Labels),
+		testApi.WithOrigin(cfg.Origin),
+		testApi.WithReporting(&cfg.Report),
+	), nil
+}
+
+func (a *analysisOrchestrator) newWorkspaceTestBody(cfg AnalysisConfig) (*testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, error) {
+	if cfg.WorkspaceId == nil {
+		return nil, errors.New("workspaceId is required")
+	}
+
+	workspace := testApi.NewTestInputWorkspace(*cfg.WorkspaceId)
+	return testApi.NewCreateTestApplicationBody(
+		testApi.WithInputWorkspace(workspace),
+		testApi.WithScanType(a.testType),
+		testApi.WithExclusionGlobs(cfg.ExclusionGlobs),
+		testApi.WithScanners(cfg.Scanners),
+		testApi.WithProjectName(cfg.ProjectName),
+		testApi.WithProjectTags(cfg.ProjectTags),
+		testApi.WithTargetName(cfg.TargetName),
+		testApi.WithTargetReference(cfg.TargetReference),
+		testApi.WithInitiator(cfg.Initiator),
+		testApi.WithLabel(cfg.Label),
+		testApi.WithLabels(cfg.Labels),
+		testApi.WithOrigin(cfg.Origin),
+		testApi.WithReporting(&cfg.Report),
+	), nil
+}
+
+// newSbomTestBody creates the body of a test of the SBOM document together with the source code. Both bundles must
+// have been uploaded the same way, either as deepcode bundles or as upload revisions.
+func (a *analysisOrchestrator) newSbomTestBody(sourceBundle bundle.Bundle, sbomBundle bundle.Bundle, target scan.Target, sbomFilePath string, cfg AnalysisConfig) (*testApi.CreateTestApplicationVndAPIPlusJSONRequestBody, error) {
+	input := testApi.WithInputSBOMSourceBundles(sbomBundle.GetBundleHash(), sbomFilePath, sourceBundle.GetBundleHash(), target.GetPath())
+
+	sourceRevision, isSourceRevision := sourceBundle.(bundle.UploadRevision)
+	sbomRevision, isSbomRevision := sbomBundle.(bundle.UploadRevision)
+	if isSourceRevision != isSbomRevision {
+		return nil, errors.New("the source code and the SBOM must both be uploaded as bundles or as upload revisions")
+	}
+	if isSourceRevision {
+		sourceRevisionId, err := uuid.Parse(sourceRevision.GetRevisionId())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid source revision id")
+		}
+		sbomRevisionId, err := uuid.Parse(sbomRevision.GetRevisionId())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SBOM revision id")
+		}
+		input = testApi.WithInputSBOMSourceRevisions(sbomRevisionId, sbomFilePath, sourceRevisionId, target.GetPath())
+	}
+
+	return testApi.NewCreateTestApplicationBody(
+		input,
+		testApi.WithScanType(a.testType),
+		testApi.WithExclusionGlobs(cfg.ExclusionGlobs),
+		testApi.WithScanners(cfg.Scanners),
+		testApi.WithProjectName(cfg.ProjectName),
+		testApi.WithProjectTags(cfg.ProjectTags),
+		testApi.WithTargetName(cfg.TargetName),
+		testApi.WithTargetReference(cfg.TargetReference),
+		testApi.WithInitiator(cfg.Initiator),
+		testApi.WithLabel(cfg.Label),
+		testApi.WithLabels(cfg.Labels),
+		testApi.WithOrigin(cfg.Origin),
+		testApi.WithReporting(&cfg.Report),
+	), nil
+}
+
+func (a *analysisOrchestrator) RunTest(ctx context.Context, orgId string, b bundle.Bundle, target scan.Target, reportingConfig AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	progressString := "Snyk Code analysis for " + target.GetPath()
+	return a.runWithApiVersion(
+		func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+			body := a.newBundleTestBody(b, target, reportingConfig)
+			return a.createTestAndGetResults(ctx, orgId, body, progressString, reportingConfig)
+		},
+		func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+			if _, ok := b.(bundle.UploadRevision); ok {
+				return nil, nil, errors.New("upload revisions are not supported by test API version " + ApiVersion20241221)
+			}
+			body := a.newBundleTestBodyV20241221(b, target, reportingConfig)
+			return a.createTestAndGetResultsV20241221(ctx, orgId, body, progressString, reportingConfig)
+		},
+	)
+}
+
+func (a *analysisOrchestrator) RunTestRemote(ctx context.Context, orgId string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	progressString := "Snyk Code analysis for remote project"
+	return a.runWithApiVersion(
+		func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+			body, err := a.newRemoteTestBody(cfg)
+			if err != nil {
+				return nil, nil, err
+			}
+			return a.createTestAndGetResults(ctx, orgId, body, progressString, cfg)
+		},
+		func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+			body, err := a.newRemoteTestBodyV20241221(cfg)
+			if err != nil {
+				return nil, nil, err
+			}
+			return a.createTestAndGetResultsV20241221(ctx, orgId, body, progressString, cfg)
+		},
+	)
+}
+
+// RunTestGitUrlCoordinates tests a revision of a repository that is accessible through an SCM integration,
+// without requiring a local checkout.
+func (a *analysisOrchestrator) RunTestGitUrlCoordinates(ctx context.Context, orgId string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	if err := a.requireApiVersion("analysis.RunTestGitUrlCoordinates", ApiVersion20250407); err != nil {
+		return nil, nil, err
+	}
+	body, err := a.newGitUrlCoordinatesTestBody(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.createTestAndGetResults(ctx, orgId, body, "Snyk Code analysis for revision "+*cfg.Revision, cfg)
+}
+
+// RunTestDiff tests only the changes between two versions of an SCM target.
+func (a *analysisOrchestrator) RunTestDiff(ctx context.Context, orgId string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	if err := a.requireApiVersion("analysis.RunTestDiff", ApiVersion20250407); err != nil {
+		return nil, nil, err
+	}
+	body, err := a.newDiffTestBody(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.createTestAndGetResults(ctx, orgId, body, "Snyk Code analysis for changes from "+*cfg.BaseVersion+" to "+*cfg.HeadVersion, cfg)
+}
+
+// RunTestWorkspace tests the content of a server-side workspace, e.g. one that was populated for a large monorepo.
+func (a *analysisOrchestrator) RunTestWorkspace(ctx context.Context, orgId string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	if err := a.requireApiVersion("analysis.RunTestWorkspace", ApiVersion20250407); err != nil {
+		return nil, nil, err
+	}
+	body, err := a.newWorkspaceTestBody(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.createTestAndGetResults(ctx, orgId, body, "Snyk Code analysis for workspace "+cfg.WorkspaceId.String(), cfg)
+}
+
+// RunTestSbom tests the uploaded SBOM document together with the uploaded source code in a single test. The SARIF
+// findings of the sast component are returned as the result; the result metadata contains the findings of every
+// component, e.g. the CycloneDX document of the SBOM component.
+func (a *analysisOrchestrator) RunTestSbom(ctx context.Context, orgId string, sourceBundle bundle.Bundle, sbomBundle bundle.Bundle, target scan.Target, sbomFilePath string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	if err := a.requireApiVersion("analysis.RunTestSbom", ApiVersion20250407); err != nil {
+		return nil, nil, err
+	}
+	body, err := a.newSbomTestBody(sourceBundle, sbomBundle, target, sbomFilePath, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.createTestAndGetResults(ctx, orgId, body, "Snyk Code analysis for "+target.GetPath()+" with SBOM "+sbomFilePath, cfg)
+}
+
+// CreateTest creates a test for the uploaded bundle and returns its id without waiting for the results.
+func (a *analysisOrchestrator) CreateTest(ctx context.Context, orgId string, b bundle.Bundle, target scan.Target, reportingConfig AnalysisConfig) (string, error) {
+	if err := a.requireApiVersion("analysis.CreateTest", ApiVersion20250407); err != nil {
+		return "", err
+	}
+	body := a.newBundleTestBody(b, target, reportingConfig)
+	return a.createTestOnly(ctx, orgId, body, reportingConfig.OnTestCreated)
+}
+
+// CreateTestRemote creates a test for a remote project and returns its id without waiting for the results.
+func (a *analysisOrchestrator) CreateTestRemote(ctx context.Context, orgId string, cfg AnalysisConfig) (string, error) {
+	if err := a.requireApiVersion("analysis.CreateTestRemote", ApiVersion20250407); err != nil {
+		return "", err
+	}
+	body, err := a.newRemoteTestBody(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	return a.createTestOnly(ctx, orgId, body, cfg.OnTestCreated)
+}
+
+// GetTestStatus retrieves the current state of a test. If the test is in the error state, the returned error
+// contains the errors reported by the service.
+func (a *analysisOrchestrator) GetTestStatus(ctx context.Context, orgId string, testId string) (scan.TestStatus, error) {
+	if err := a.requireApiVersion("analysis.GetTestStatus", ApiVersion20250407); err != nil {
+		return "", err
+	}
+	orgUuid, err := uuid.Parse(orgId)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid orgId")
+	}
+	testUuid, err := uuid.Parse(testId)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid testId")
+	}
+
+	client, err := a.newTestClient()
+	if err != nil {
+		return "", err
+	}
+
+	state, err := a.retrieveTestState(ctx, client, orgUuid, testUuid)
+	if state.retryable {
+		return "", scan.ThrottledError{RetryAfter: state.retryAfter, Err: err}
+	}
+	return state.status, err
+}
+
+// GetTestConfiguration retrieves the effective configuration the test service resolved for a test, including the
+// settings that were applied from the organization.
+func (a *analysisOrchestrator) GetTestConfiguration(ctx context.Context, orgId string, testId string) (*scan.TestConfiguration, error) {
+	method := "analysis.GetTestConfiguration"
+	logger := a.logger.With().Str("method", method).Logger()
+	if err := a.requireApiVersion(method, ApiVersion20250407); err != nil {
+		return nil, err
+	}
+
+	orgUuid, err := uuid.Parse(orgId)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid orgId")
+	}
+	testUuid, err := uuid.Parse(testId)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid testId")
+	}
+
+	client, err := a.newTestClient()
+	if err != nil {
+		return nil, err
+	}
+
+	httpResponse, err := client.GetTestInitialConfiguration(
+		ctx,
+		orgUuid,
+		testUuid,
+		&testApi.GetTestInitialConfigurationParams{Version: testApi.ApiVersion},
 	)
+	if err != nil {
+		logger.Err(err).Str("testId", testId).Msg("error requesting the test configuration")
+		return nil, err
+	}
+	defer func() {
+		closeErr := httpResponse.Body.Close()
+		if closeErr != nil {
+			a.logger.Err(closeErr).Msg("failed to close response body")
+		}
+	}()
+
+	parsedResponse, err := testApi.ParseGetTestInitialConfigurationResponse(httpResponse)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkApiVersionHeaders(httpResponse, testApi.ApiVersion); err != nil {
+		return nil, err
+	}
+
+	if parsedResponse.ApplicationvndApiJSON200 == nil {
+		return nil, fmt.Errorf("%s: %w", method, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body))
+	}
+
+	result := &scan.TestConfiguration{}
+	data := parsedResponse.ApplicationvndApiJSON200.Data
+	if data == nil || data.Attributes == nil || data.Attributes.Configuration == nil {
+		return result, nil
+	}
+	configuration := data.Attributes.Configuration
+
+	if scanConfig := configuration.Scan; scanConfig != nil {
+		if scanConfig.Scanners != nil {
+			for _, scanner := range *scanConfig.Scanners {
+				result.Scanners = append(result.Scanners, string(scanner))
+			}
+		}
+		if scanConfig.ResultType != nil {
+			result.ResultType = string(*scanConfig.ResultType)
+		}
+		result.ExclusionGlobs = valueOrZero(scanConfig.ExclusionGlobs)
+		result.LimitTestToFiles = valueOrZero(scanConfig.LimitTestToFiles)
+	}
+
+	if output := configuration.Output; output != nil {
+		result.Report = valueOrZero(output.Report)
+		if output.ProjectId != nil {
+			result.ProjectId = output.ProjectId.String()
+		}
+		result.ProjectName = valueOrZero(output.ProjectName)
+		result.ProjectTags = valueOrZero(output.ProjectTags)
+		result.TargetName = valueOrZero(output.TargetName)
+		result.TargetReference = valueOrZero(output.TargetReference)
+		if output.Initiator != nil {
+			result.Initiator = string(*output.Initiator)
+		}
+		result.Origin = valueOrZero(output.Origin)
+		result.Label = valueOrZero(output.Label)
+		result.Labels = valueOrZero(output.Labels)
+	}
+
+	return result, nil
+}
+
+func valueOrZero[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+	return *value
+}
+
+// ResumeTest continues polling an already created test and retrieves its findings. It can be used to pick up
+// an analysis that was interrupted after the test had been created, e.g. because the process was restarted.
+func (a *analysisOrchestrator) ResumeTest(ctx context.Context, orgId string, testId string) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	if err := a.requireApiVersion("analysis.ResumeTest", ApiVersion20250407); err != nil {
+		return nil, nil, err
+	}
+	orgUuid, err := uuid.Parse(orgId)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid orgId")
+	}
+	testUuid, err := uuid.Parse(testId)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid testId")
+	}
+
+	tracker := a.trackerFactory.GenerateTracker()
+	tracker.Begin("Snyk Code analysis for test "+testId, "Retrieving results...")
+
+	innerFunction := func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+		client, clientErr := a.newTestClient()
+		if clientErr != nil {
+			return nil, nil, clientErr
+		}
+		return a.pollTestForFindings(ctx, client, orgUuid, testUuid, nil)
+	}
+
+	result, metadata, err := innerFunction()
+	if err != nil {
+		tracker.End("Analysis failed.")
+	} else {
+		tracker.End("Analysis completed.")
+	}
+
+	return result, metadata, err
+}
+
+func (a *analysisOrchestrator) pollTestForFindings(ctx context.Context, client *testApi.Client, org uuid.UUID, testId openapi_types.UUID, streamHandler *sarif.StreamHandler) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	var resultMetaData *scan.ResultMetaData
+	err := a.pollUntilComplete(ctx, testId, func() (bool, time.Duration, error) {
+		var complete bool
+		var retryAfter time.Duration
+		var err error
+		resultMetaData, complete, retryAfter, err = a.retrieveTestURL(ctx, client, org, testId)
+		return complete, retryAfter, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return a.createTestAndGetResults(ctx, orgId, body, "Snyk Code analysis for remote project")
+	resultMetaData.TestId = testId.String()
+	if a.summaryOnly {
+		return nil, resultMetaData, nil
+	}
+	findings, err := a.retrieveFindings(ctx, testId, resultMetaData.FindingsUrl, streamHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = a.retrieveComponentDocuments(ctx, resultMetaData, findings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return findings, resultMetaData, nil
 }
 
-func (a *analysisOrchestrator) pollTestForFindings(ctx context.Context, client *testApi.Client, org uuid.UUID, testId openapi_types.UUID) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
-	method := "analysis.pollTestForFindings"
+// pollUntilComplete calls check following the polling strategy until it reports the test as complete, returns an
+// error, the context is cancelled or the analysis times out.
+func (a *analysisOrchestrator) pollUntilComplete(ctx context.Context, testId openapi_types.UUID, check func() (complete bool, retryAfter time.Duration, err error)) error {
+	method := "analysis.pollUntilComplete"
 	logger := a.logger.With().Str("method", method).Logger()
 
-	pollingTicker := time.NewTicker(1 * time.Second)
-	defer pollingTicker.Stop()
-	timeoutTimer := time.NewTimer(a.config.SnykCodeAnalysisTimeout())
+	backoff := a.pollingStrategy.NewBackoff()
+	pollingTimer := time.NewTimer(backoff.Next(0))
+	defer pollingTimer.Stop()
+	timeout := a.config.SnykCodeAnalysisTimeout()
+	deadline := time.Now().Add(timeout)
+	timeoutTimer := time.NewTimer(timeout)
 	defer timeoutTimer.Stop()
+	timedOut := func() error {
+		msg := "Snyk Code analysis timed out"
+		logger.Error().Str("scanJobId", testId.String()).Msg(msg)
+		return fmt.Errorf("%s: %w", msg, context.DeadlineExceeded)
+	}
 	for {
 		select {
+		case <-ctx.Done():
+			return ctx.Err()
 		case <-timeoutTimer.C:
-			msg := "Snyk Code analysis timed out"
-			logger.Error().Str("scanJobId", testId.String()).Msg(msg)
-			return nil, nil, fmt.Errorf("%s: %w", msg, context.DeadlineExceeded)
-		case <-pollingTicker.C:
-			resultMetaData, complete, err := a.retrieveTestURL(ctx, client, org, testId)
+			return timedOut()
+		case <-pollingTimer.C:
+			complete, retryAfter, err := check()
 			if err != nil {
-				return nil, nil, err
+				return err
 			}
 			if complete {
-				findings, findingsErr := a.retrieveFindings(ctx, testId, resultMetaData.FindingsUrl)
-				if findingsErr != nil {
-					return nil, nil, findingsErr
-				}
-				return findings, resultMetaData, nil
+				return nil
+			}
+			// never wait beyond the timeout of the analysis
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return timedOut()
 			}
+			pollingTimer.Reset(min(backoff.Next(retryAfter), remaining))
 		}
 	}
 }
 
-func (a *analysisOrchestrator) retrieveTestURL(ctx context.Context, client *testApi.Client, org uuid.UUID, testId openapi_types.UUID) (resultMetaData *scan.ResultMetaData, completed bool, err error) {
-	method := "analysis.retrieveTestURL"
+func (a *analysisOrchestrator) retrieveTestURL(ctx context.Context, client *testApi.Client, org uuid.UUID, testId openapi_types.UUID) (resultMetaData *scan.ResultMetaData, completed bool, retryAfter time.Duration, err error) {
+	state, err := a.retrieveTestState(ctx, client, org, testId)
+	if state.retryable {
+		return nil, false, state.retryAfter, nil
+	}
+	if err != nil || state.status != scan.TestStatusCompleted {
+		return nil, false, state.retryAfter, err
+	}
+
+	resultMetaData, err = a.retrieveTestComponents(ctx, client, org, testId)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	resultMetaData.TestResult = state.result
+	resultMetaData.CreatedAt = state.createdAt
+
+	return resultMetaData, true, 0, nil
+}
+
+// testState is the state of a test as reported by the test service. The result and creation time are only set
+// once the test has completed. A retryable state comes with the error of a request that was throttled or hit an
+// unavailable service, it is worth polling again after retryAfter.
+type testState struct {
+	status     scan.TestStatus
+	result     scan.TestResult
+	createdAt  time.Time
+	retryAfter time.Duration
+	retryable  bool
+}
+
+// retryableStatusCodes are the status codes of test state requests that are worth repeating, honoring the
+// Retry-After header of the response.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+func (a *analysisOrchestrator) retrieveTestState(ctx context.Context, client *testApi.Client, org uuid.UUID, testId openapi_types.UUID) (testState, error) {
+	method := "analysis.retrieveTestState"
 	logger := a.logger.With().Str("method", method).Logger()
-	logger.Debug().Msg("retrieving Test URL")
+	logger.Debug().Msg("retrieving Test state")
 
 	httpResponse, err := client.GetTestResult(
 		ctx,
@@ -324,7 +973,7 @@ func (a *analysisOrchestrator) retrieveTestURL(ctx context.Context, client *test
 	)
 	if err != nil {
 		logger.Err(err).Str("testId", testId.String()).Msg("error requesting the ScanJobResult")
-		return nil, false, err
+		return testState{}, err
 	}
 	defer func() {
 		closeErr := httpResponse.Body.Close()
@@ -335,40 +984,46 @@ func (a *analysisOrchestrator) retrieveTestURL(ctx context.Context, client *test
 
 	parsedResponse, err := testApi.ParseGetTestResultResponse(httpResponse)
 	if err != nil {
-		return nil, false, err
+		return testState{}, err
+	}
+	retryAfter := codeClientHTTP.RetryAfter(httpResponse)
+	if err = a.checkApiVersionHeaders(httpResponse, testApi.ApiVersion); err != nil {
+		return testState{}, err
 	}
 
-	switch parsedResponse.StatusCode() {
-	case 200:
-		stateDiscriminator, stateError := parsedResponse.ApplicationvndApiJSON200.Data.Attributes.Discriminator()
-		if stateError != nil {
-			return nil, false, stateError
-		}
+	if retryableStatusCodes[parsedResponse.StatusCode()] {
+		logger.Debug().Int("statusCode", parsedResponse.StatusCode()).Dur("retryAfter", retryAfter).Msg("test state is not available yet")
+		return testState{retryAfter: retryAfter, retryable: true}, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
+	}
+	if parsedResponse.StatusCode() != http.StatusOK || parsedResponse.ApplicationvndApiJSON200 == nil {
+		return testState{}, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
+	}
 
-		switch stateDiscriminator {
-		case string(testModels.Accepted):
-			fallthrough
-		case string(testModels.InProgress):
-			return nil, false, nil
-		case string(testModels.Completed):
-			_, stateCompleteError := parsedResponse.ApplicationvndApiJSON200.Data.Attributes.AsTestCompletedState()
-			if stateCompleteError != nil {
-				return nil, false, stateCompleteError
-			}
-			components, err := a.retrieveTestComponents(ctx, client, org, testId)
-			if err != nil {
-				return nil, false, err
-			}
+	stateDiscriminator, stateError := parsedResponse.ApplicationvndApiJSON200.Data.Attributes.Discriminator()
+	if stateError != nil {
+		return testState{}, stateError
+	}
 
-			return components, true, nil
-		case string(testModels.Error):
-			testError := parseTestError(parsedResponse, method)
-			return nil, false, testError
-		default:
-			return nil, false, fmt.Errorf("unexpected test status \"%s\"", stateDiscriminator)
+	switch stateDiscriminator {
+	case string(testModels.Accepted):
+		return testState{status: scan.TestStatusAccepted, retryAfter: retryAfter}, nil
+	case string(testModels.InProgress):
+		return testState{status: scan.TestStatusInProgress, retryAfter: retryAfter}, nil
+	case string(testModels.Completed):
+		completedState, stateCompleteError := parsedResponse.ApplicationvndApiJSON200.Data.Attributes.AsTestCompletedState()
+		if stateCompleteError != nil {
+			return testState{}, stateCompleteError
 		}
+		return testState{
+			status:    scan.TestStatusCompleted,
+			result:    scan.TestResult(completedState.Result),
+			createdAt: completedState.CreatedAt,
+		}, nil
+	case string(testModels.Error):
+		testError := parseTestError(parsedResponse, method)
+		return testState{status: scan.TestStatusError}, testError
 	default:
-		return nil, false, fmt.Errorf("unexpected response status \"%d\"", parsedResponse.StatusCode())
+		return testState{}, fmt.Errorf("unexpected test status \"%s\"", stateDiscriminator)
 	}
 }
 
@@ -385,19 +1040,7 @@ func parseTestError(parsedResponse *testApi.GetTestResultResponse, method string
 
 	var testError error
 	for _, error := range *errorResponse.Errors {
-		// since the error is only partially defined, we to create an existing generic error and fill it with the available information
-		tmp := cli.NewGeneralCLIFailureError(error.Message)
-		tmp.Level = "error"
-		tmp.ErrorCode = error.ErrorCode
-		tmp.Title = error.Title
-		tmp.StatusCode = parsedResponse.StatusCode()
-		tmp.Classification = error.Classification
-
-		if error.InfoUrl != nil {
-			tmp.Type = *error.InfoUrl
-			tmp.Links = []string{}
-		}
-		testError = goerrors.Join(testError, tmp)
+		testError = goerrors.Join(testError, newTestStateError(parsedResponse.StatusCode(), error.Classification, error.ErrorCode, error.Title, error.Message, error.InfoUrl))
 	}
 
 	if testError == nil {
@@ -406,73 +1049,127 @@ func parseTestError(parsedResponse *testApi.GetTestResultResponse, method string
 	return testError
 }
 
+// newTestStateError creates an error catalog error from an error reported in the error state of a test.
+func newTestStateError(statusCode int, classification, errorCode, title, message string, infoUrl *string) error {
+	// since the error is only partially defined, we to create an existing generic error and fill it with the available information
+	testError := cli.NewGeneralCLIFailureError(message)
+	testError.Level = "error"
+	testError.ErrorCode = errorCode
+	testError.Title = title
+	testError.StatusCode = statusCode
+	testError.Classification = classification
+
+	if infoUrl != nil {
+		testError.Type = *infoUrl
+		testError.Links = []string{}
+	}
+	return testError
+}
+
 func (a *analysisOrchestrator) retrieveTestComponents(ctx context.Context, client *testApi.Client, org uuid.UUID, testId openapi_types.UUID) (*scan.ResultMetaData, error) {
 	method := "analysis.retrieveTestComponents"
 	logger := a.logger.With().Str("method", method).Logger()
 	logger.Debug().Msg("retrieving Test Components")
 
-	httpResponse, err := client.GetComponents(
+	data, err := client.GetAllComponents(
 		ctx,
 		org,
 		testId,
 		&testApi.GetComponentsParams{Version: testApi.ApiVersion},
 	)
-
 	if err != nil {
 		logger.Err(err).Str("testId", testId.String()).Msg("error requesting the test components")
-		return nil, err
+		return nil, fmt.Errorf("%s: %w", method, err)
 	}
 
-	defer func() {
-		closeErr := httpResponse.Body.Close()
-		if closeErr != nil {
-			a.logger.Err(closeErr).Msg("failed to close response body")
-		}
-	}()
+	result := &scan.ResultMetaData{Components: make([]scan.TestComponent, 0, len(data))}
+	for _, item := range data {
+		a.logger.Trace().Msgf("inner component: %+v", item)
+		component := a.newTestComponent(item.Attributes)
+		result.SeverityCounts = result.SeverityCounts.Add(component.SeverityCounts)
+		result.Components = append(result.Components, component)
+	}
 
-	parsedResponse, err := testApi.ParseGetComponentsResponse(httpResponse)
-	if err != nil {
-		return nil, err
+	primaryComponent := findPrimaryComponent(result.Components)
+	if primaryComponent == nil {
+		return nil, fmt.Errorf("%s: no sast component found", method)
 	}
 
-	if parsedResponse.ApplicationvndApiJSON200 == nil {
-		return nil, fmt.Errorf("%s: unexpected response status \"%d\"", method, parsedResponse.StatusCode())
+	if !primaryComponent.Success {
+		return nil, fmt.Errorf("%s: %s scan did not complete successfully", method, primaryComponent.Type)
 	}
-	data := parsedResponse.ApplicationvndApiJSON200.Data
-	var sastComponent *testModels.GetComponentsResponseItem
-	for _, component := range data {
-		if component.Attributes.Type == "sast" {
-			a.logger.Trace().Msgf("inner component: %+v", component)
-			sastComponent = &component
-			break
+
+	if primaryComponent.FindingsDocumentType == scan.FindingsDocumentTypeSarif {
+		result.FindingsUrl = primaryComponent.FindingsUrl
+		result.WebUiUrl = primaryComponent.WebUiUrl
+		result.ProjectId = primaryComponent.ProjectId
+		result.SnapshotId = primaryComponent.SnapshotId
+	}
+	return result, nil
+}
+
+func (a *analysisOrchestrator) newTestComponent(attributes testModels.ComponentAttributes) scan.TestComponent {
+	component := scan.TestComponent{
+		Id:      attributes.Id,
+		Type:    attributes.Type,
+		Success: attributes.Success,
+	}
+	if attributes.Name != nil {
+		component.Name = *attributes.Name
+	}
+	if attributes.SeverityCounts != nil {
+		component.SeverityCounts = newSeverityCounts(*attributes.SeverityCounts)
+	}
+	if attributes.Webui != nil {
+		if attributes.Webui.Link != nil {
+			component.WebUiUrl = *attributes.Webui.Link
+		}
+		if attributes.Webui.ProjectId != nil {
+			component.ProjectId = attributes.Webui.ProjectId.String()
+		}
+		if attributes.Webui.SnapshotId != nil {
+			component.SnapshotId = attributes.Webui.SnapshotId.String()
 		}
 	}
-	if sastComponent == nil {
-		return nil, fmt.Errorf("%s: no sast component found", method)
+	if attributes.FindingsDocumentType != nil {
+		component.FindingsDocumentType = scan.FindingsDocumentType(*attributes.FindingsDocumentType)
 	}
-
-	result := &scan.ResultMetaData{}
-	attributes := sastComponent.Attributes
-
-	if !attributes.Success {
-		return nil, fmt.Errorf("%s: sast scan did not complete successfully", method)
+	if attributes.FindingsDocumentPath != nil {
+		component.FindingsUrl = a.host(true) + *attributes.FindingsDocumentPath + "?version=" + testApi.DocumentApiVersion
 	}
+	return component
+}
 
-	if attributes.FindingsDocumentType != nil && *attributes.FindingsDocumentType == testModels.Sarif {
-		findingsUrl := a.host(true) + *attributes.FindingsDocumentPath + "?version=" + testApi.DocumentApiVersion
-		result.FindingsUrl = findingsUrl
+func newSeverityCounts(counts testModels.SeverityCounts) scan.SeverityCounts {
+	result := scan.SeverityCounts{}
+	if counts.Critical != nil {
+		result.Critical = *counts.Critical
+	}
+	if counts.High != nil {
+		result.High = *counts.High
+	}
+	if counts.Medium != nil {
+		result.Medium = *counts.Medium
+	}
+	if counts.Low != nil {
+		result.Low = *counts.Low
+	}
+	return result
+}
 
-		if attributes.Webui != nil {
-			if attributes.Webui.Link != nil {
-				result.WebUiUrl = *attributes.Webui.Link
-			}
-			if attributes.Webui.ProjectId != nil {
-				result.ProjectId = attributes.Webui.ProjectId.String()
-			}
-			if attributes.Webui.SnapshotId != nil {
-				result.SnapshotId = attributes.Webui.SnapshotId.String()
-			}
+// findPrimaryComponent returns the component whose SARIF findings are returned as the analysis result. This is the
+// sast component if there is one, otherwise the first component with a SARIF findings document, e.g. when only the
+// secrets scanner was selected.
+func findPrimaryComponent(components []scan.TestComponent) *scan.TestComponent {
+	for i := range components {
+		if components[i].Type == "sast" {
+			return &components[i]
 		}
 	}
-	return result, nil
+	for i := range components {
+		if components[i].FindingsDocumentType == scan.FindingsDocumentTypeSarif {
+			return &components[i]
+		}
+	}
+	return nil
 }
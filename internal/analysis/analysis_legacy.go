@@ -73,6 +73,24 @@ type FailedError struct {
 
 func (e FailedError) Error() string { return e.Msg }
 
+// LegacyRequestContext describes the client of a legacy analysis to the server, which uses it to attribute the usage.
+// Empty fields fall back to the defaults of the language server.
+type LegacyRequestContext struct {
+	// Initiator is what triggered the analysis. If it is empty, it is derived from the scan.ScanSource of the context.
+	Initiator      string
+	Flow           string
+	OrgName        string
+	OrgDisplayName string
+	OrgFlags       map[string]bool
+}
+
+// WithLegacyRequestContext sets the context that is sent with every legacy analysis request.
+func WithLegacyRequestContext(requestContext LegacyRequestContext) func(*analysisOrchestrator) {
+	return func(a *analysisOrchestrator) {
+		a.legacyRequestContext = requestContext
+	}
+}
+
 // Legacy analysis helper functions
 func (a *analysisOrchestrator) newRequestContext(ctx context.Context) requestContext {
 	unknown := "unknown"
@@ -88,17 +106,25 @@ func (a *analysisOrchestrator) newRequestContext(ctx context.Context) requestCon
 	}
 
 	return requestContext{
-		Initiator: initiator,
-		Flow:      "language-server",
+		Initiator: valueOrDefault(a.legacyRequestContext.Initiator, initiator),
+		Flow:      valueOrDefault(a.legacyRequestContext.Flow, "language-server"),
 		Org: requestContextOrg{
-			Name:        unknown,
-			DisplayName: unknown,
+			Name:        valueOrDefault(a.legacyRequestContext.OrgName, unknown),
+			DisplayName: valueOrDefault(a.legacyRequestContext.OrgDisplayName, unknown),
 			PublicId:    orgId,
+			Flags:       a.legacyRequestContext.OrgFlags,
 		},
 	}
 }
 
-func (a *analysisOrchestrator) createRequestBody(ctx context.Context, bundleHash, shardKey string, limitToFiles []string, severity int) ([]byte, error) {
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func (a *analysisOrchestrator) createRequestBody(ctx context.Context, bundleHash, shardKey string, limitToFiles []string, severity int, prioritized bool) ([]byte, error) {
 	request := Request{
 		Key: RequestKey{
 			Type:         "file",
@@ -106,6 +132,7 @@ func (a *analysisOrchestrator) createRequestBody(ctx context.Context, bundleHash
 			LimitToFiles: limitToFiles,
 		},
 		Legacy:          false,
+		Prioritized:     prioritized,
 		AnalysisContext: a.newRequestContext(ctx),
 	}
 	if len(shardKey) > 0 {
@@ -140,7 +167,7 @@ func (a *analysisOrchestrator) getCodeApiUrl() (string, error) {
 	return u.String(), nil
 }
 
-func (a *analysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash string, shardKey string, limitToFiles []string, severity int) (*sarif.SarifResponse, scan.LegacyScanStatus, error) {
+func (a *analysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash string, shardKey string, limitToFiles []string, severity int, prioritized bool) (*sarif.SarifResponse, scan.LegacyScanStatus, error) {
 	method := "analysis.RunLegacyTest"
 	span := a.instrumentor.StartSpan(ctx, method)
 	defer a.instrumentor.Finish(span)
@@ -148,7 +175,7 @@ func (a *analysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash str
 	a.logger.Debug().Str("method", method).Str("bundleHash", bundleHash).Msg("API: Retrieving analysis for bundle")
 	defer a.logger.Debug().Str("method", method).Str("bundleHash", bundleHash).Msg("API: Retrieving analysis done")
 
-	requestBody, err := a.createRequestBody(ctx, bundleHash, shardKey, limitToFiles, severity)
+	requestBody, err := a.createRequestBody(ctx, bundleHash, shardKey, limitToFiles, severity, prioritized)
 	if err != nil {
 		a.logger.Err(err).Str("method", method).Str("requestBody", string(requestBody)).Msg("error creating request body")
 		return nil, scan.LegacyScanStatus{}, err
@@ -233,10 +260,38 @@ func (a *analysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash str
 		return nil, failed, FailedError{Msg: string(responseBody)}
 	}
 
-	status := scan.LegacyScanStatus{Message: response.Status, Percentage: int(math.RoundToEven(response.Progress * 100))}
+	status := newLegacyScanStatus(&response)
 	if response.Status != StatusComplete {
 		return nil, status, nil
 	}
 
 	return &response, status, nil
 }
+
+// newLegacyScanStatus reports the progress, timing and coverage of the analysis. While the analysis is running, it
+// also passes on the findings the server has already returned.
+func newLegacyScanStatus(response *sarif.SarifResponse) scan.LegacyScanStatus {
+	status := scan.LegacyScanStatus{
+		Message:    response.Status,
+		Percentage: int(math.RoundToEven(response.Progress * 100)),
+		Timing: scan.LegacyScanTiming{
+			FetchingCode: response.Timing.FetchingCode,
+			Queue:        response.Timing.Queue,
+			Analysis:     response.Timing.Analysis,
+		},
+		Coverage: response.Coverage,
+	}
+	if response.Status != StatusComplete && hasResults(response.Sarif) {
+		status.PartialResults = &response.Sarif
+	}
+	return status
+}
+
+func hasResults(document sarif.SarifDocument) bool {
+	for _, run := range document.Runs {
+		if len(run.Results) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -180,6 +180,7 @@ func TestAnalysis_RunLegacyTest_Success(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.NoError(t, err)
@@ -234,12 +235,54 @@ func TestAnalysis_RunLegacyTest_InProgress(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.NoError(t, err)
 	assert.Nil(t, result) // No result when not complete
 	assert.Equal(t, analysis.StatusAnalyzing, status.Message)
 	assert.Equal(t, 60, status.Percentage) // 0.6 * 100
+	assert.Equal(t, scan.LegacyScanTiming{FetchingCode: 50, Analysis: 200}, status.Timing)
+	assert.Nil(t, status.PartialResults)
+}
+
+func TestAnalysis_RunLegacyTest_InProgressWithPartialResults(t *testing.T) {
+	bundleHash := "test-bundle-hash"
+	orgId := "test-org-id"
+
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, _, mockTrackerFactory, logger := setupLegacy(t, nil, false, "", orgId)
+
+	sarifResponse := sarif.SarifResponse{
+		Type:     "sarif",
+		Progress: 0.3,
+		Status:   analysis.StatusAnalyzing,
+		Coverage: []sarif.SarifCoverage{{Files: 3, IsSupported: true, Lang: "Java"}},
+		Sarif: sarif.SarifDocument{
+			Version: "2.1.0",
+			Runs:    []sarif.Run{{Results: []sarif.Result{{RuleID: "java/Sqli"}}}},
+		},
+	}
+
+	mockLegacyAnalysisResponse(t, mockHTTPClient, sarifResponse, bundleHash, orgId, http.StatusOK)
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+	)
+
+	result, status, err := analysisOrchestrator.RunLegacyTest(t.Context(), bundleHash, "", []string{}, 0, false)
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, analysis.StatusAnalyzing, status.Message)
+	assert.Equal(t, sarifResponse.Coverage, status.Coverage)
+	require.NotNil(t, status.PartialResults)
+	require.Len(t, status.PartialResults.Runs, 1)
+	assert.Equal(t, "java/Sqli", status.PartialResults.Runs[0].Results[0].RuleID)
 }
 
 func TestAnalysis_RunLegacyTest_Failed(t *testing.T) {
@@ -276,6 +319,7 @@ func TestAnalysis_RunLegacyTest_Failed(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.Error(t, err)
@@ -318,6 +362,7 @@ func TestAnalysis_RunLegacyTest_EmptyStatus(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.Error(t, err)
@@ -364,6 +409,7 @@ func TestAnalysis_RunLegacyTest_HTTPError(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.Error(t, err)
@@ -424,6 +470,7 @@ func TestAnalysis_RunLegacyTest_Fedramp(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.NoError(t, err)
@@ -457,6 +504,7 @@ func TestAnalysis_RunLegacyTest_FedrampNoOrg(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.Error(t, err)
@@ -503,6 +551,7 @@ func TestAnalysis_RunLegacyTest_MalformedJSON(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.Error(t, err)
@@ -563,6 +612,7 @@ func TestAnalysis_CreateRequestBody(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		true,
 	)
 
 	require.NoError(t, err)
@@ -597,6 +647,7 @@ func TestAnalysis_CreateRequestBody(t *testing.T) {
 
 	// Validate severity
 	assert.Equal(t, float64(severity), request["severity"])
+	assert.Equal(t, true, request["prioritized"])
 
 	// Validate analysisContext
 	analysisContext := request["analysisContext"].(map[string]interface{})
@@ -609,6 +660,72 @@ func TestAnalysis_CreateRequestBody(t *testing.T) {
 	assert.Equal(t, "unknown", org["displayName"])
 }
 
+func TestAnalysis_CreateRequestBody_WithLegacyRequestContext(t *testing.T) {
+	orgId := "test-org-id"
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, _, mockTrackerFactory, logger := setupLegacy(t, nil, false, "", orgId)
+
+	var capturedRequestBody []byte
+	expectedAnalysisUrl := "http://localhost/analysis"
+	mockHTTPClient.EXPECT().Do(mock.MatchedBy(func(i interface{}) bool {
+		req := i.(*http.Request)
+		if req.URL.String() == expectedAnalysisUrl && req.Method == http.MethodPost {
+			body, _ := io.ReadAll(req.Body)
+			capturedRequestBody = body
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			return true
+		}
+		return false
+	})).Times(1).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body: io.NopCloser(bytes.NewReader([]byte(`{"type":"sarif","progress":1.0,"status":"COMPLETE"}`))),
+	}, nil)
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+		analysis.WithLegacyRequestContext(analysis.LegacyRequestContext{
+			Initiator:      "cli_test",
+			Flow:           "cli",
+			OrgName:        "my-org",
+			OrgDisplayName: "My Org",
+			OrgFlags:       map[string]bool{"sast": true},
+		}),
+	)
+
+	// the explicit initiator wins over the scan source of the context
+	_, _, err := analysisOrchestrator.RunLegacyTest(
+		scan.NewContextWithScanSource(t.Context(), scan.IDE),
+		"test-bundle-hash",
+		"",
+		[]string{},
+		0,
+		false,
+	)
+	require.NoError(t, err)
+
+	decodedRequestBody, err := decodeRequestBody(capturedRequestBody)
+	require.NoError(t, err)
+	var request map[string]interface{}
+	require.NoError(t, json.Unmarshal(decodedRequestBody, &request))
+
+	analysisContext := request["analysisContext"].(map[string]interface{})
+	assert.Equal(t, "cli_test", analysisContext["initiator"])
+	assert.Equal(t, "cli", analysisContext["flow"])
+
+	org := analysisContext["org"].(map[string]interface{})
+	assert.Equal(t, orgId, org["publicId"])
+	assert.Equal(t, "my-org", org["name"])
+	assert.Equal(t, "My Org", org["displayName"])
+	assert.Equal(t, map[string]interface{}{"sast": true}, org["flags"])
+}
+
 func TestAnalysis_CreateRequestBody_NoShardKey(t *testing.T) {
 	bundleHash := "test-bundle-hash"
 	shardKey := "" // Empty shard key
@@ -653,6 +770,7 @@ func TestAnalysis_CreateRequestBody_NoShardKey(t *testing.T) {
 		shardKey,
 		limitToFiles,
 		severity,
+		false,
 	)
 
 	require.NoError(t, err)
@@ -702,7 +820,7 @@ func TestAnalysis_GetCodeApiUrl_Regular(t *testing.T) {
 		analysis.WithErrorReporter(mockErrorReporter),
 	)
 
-	_, _, err := analysisOrchestrator.RunLegacyTest(t.Context(), "hash", "", []string{}, 0)
+	_, _, err := analysisOrchestrator.RunLegacyTest(t.Context(), "hash", "", []string{}, 0, false)
 	require.NoError(t, err)
 }
 
@@ -733,7 +851,7 @@ func TestAnalysis_GetCodeApiUrl_Fedramp(t *testing.T) {
 		analysis.WithErrorReporter(mockErrorReporter),
 	)
 
-	_, _, err := analysisOrchestrator.RunLegacyTest(t.Context(), "hash", "", []string{}, 0)
+	_, _, err := analysisOrchestrator.RunLegacyTest(t.Context(), "hash", "", []string{}, 0, false)
 	require.NoError(t, err)
 }
 
@@ -751,7 +869,7 @@ func TestAnalysis_GetCodeApiUrl_InvalidURL(t *testing.T) {
 		analysis.WithErrorReporter(mockErrorReporter),
 	)
 
-	_, _, err := analysisOrchestrator.RunLegacyTest(t.Context(), "hash", "", []string{}, 0)
+	_, _, err := analysisOrchestrator.RunLegacyTest(t.Context(), "hash", "", []string{}, 0, false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid")
 }
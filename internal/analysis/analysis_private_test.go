@@ -61,7 +61,7 @@ func TestAnalysis_retrieveTestURL_TestResultError(t *testing.T) {
 }`)),
 	}, nil)
 
-	resultMetaData, completed, err := analysisOrchestrator.retrieveTestURL(t.Context(), apiClient, uuid.New(), uuid.New())
+	resultMetaData, completed, _, err := analysisOrchestrator.retrieveTestURL(t.Context(), apiClient, uuid.New(), uuid.New())
 	assert.Error(t, err)
 	assert.False(t, completed)
 	assert.Nil(t, resultMetaData)
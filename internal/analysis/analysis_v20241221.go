@@ -0,0 +1,323 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+//nolint:lll // Some of the lines in this file are going to be long for now.
+package analysis
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/pkg/errors"
+
+	"github.com/snyk/code-client-go/bundle"
+	codeClientHTTP "github.com/snyk/code-client-go/http"
+	testApiV20241221 "github.com/snyk/code-client-go/internal/api/test/2024-12-21"
+	testModelsV20241221 "github.com/snyk/code-client-go/internal/api/test/2024-12-21/models"
+	testApi "github.com/snyk/code-client-go/internal/api/test/2025-04-07"
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+// This file implements tests against version 2024-12-21 of the test API, which the orchestrator falls back to once
+// the newer version has been sunset. The older version reports a single findings document per test and no
+// components, so the result metadata only contains the outcome of the test and its web UI links.
+
+func (a *analysisOrchestrator) newTestClientV20241221() (*testApiV20241221.Client, error) {
+	return testApiV20241221.NewClient(a.host(true), testApiV20241221.WithHTTPClient(a.httpClient))
+}
+
+// unsupportedOptionsV20241221 returns the names of the options of the configuration that version 2024-12-21 of the
+// test API has no field for.
+func unsupportedOptionsV20241221(cfg AnalysisConfig) []string {
+	var unsupported []string
+	if cfg.ExclusionGlobs != nil {
+		unsupported = append(unsupported, "exclusionGlobs")
+	}
+	if cfg.Scanners != nil {
+		unsupported = append(unsupported, "scanners")
+	}
+	if cfg.Initiator != nil {
+		unsupported = append(unsupported, "initiator")
+	}
+	if cfg.Label != nil {
+		unsupported = append(unsupported, "label")
+	}
+	if cfg.Labels != nil {
+		unsupported = append(unsupported, "labels")
+	}
+	if cfg.Origin != nil {
+		unsupported = append(unsupported, "origin")
+	}
+	return unsupported
+}
+
+// warnDroppedOptionsV20241221 logs the options that are not sent, as the test runs with the older version of the test
+// API after a fallback.
+func (a *analysisOrchestrator) warnDroppedOptionsV20241221(dropped []string) {
+	if len(dropped) == 0 {
+		return
+	}
+	a.logger.Warn().Str("apiVersion", ApiVersion20241221).Strs("droppedOptions", dropped).Msg("test API version does not support some of the options, they are ignored")
+}
+
+func (a *analysisOrchestrator) newBundleTestBodyV20241221(b bundle.Bundle, target scan.Target, reportingConfig AnalysisConfig) *testApiV20241221.CreateTestApplicationVndAPIPlusJSONRequestBody {
+	dropped := unsupportedOptionsV20241221(reportingConfig)
+	if reportingConfig.ProjectTags != nil {
+		dropped = append(dropped, "projectTags")
+	}
+
+	var repoUrl *string = nil
+	if repoTarget, ok := target.(*scan.RepositoryTarget); ok {
+		tmpRepoUrl := repoTarget.GetRepositoryUrl()
+		if len(tmpRepoUrl) > 0 {
+			repoUrl = &tmpRepoUrl
+		}
+		if len(repoTarget.GetCommitId()) > 0 {
+			dropped = append(dropped, "commitId")
+		}
+		if len(repoTarget.GetBranchName()) > 0 {
+			dropped = append(dropped, "branch")
+		}
+	}
+	a.warnDroppedOptionsV20241221(dropped)
+
+	return testApiV20241221.NewCreateTestApplicationBody(
+		testApiV20241221.WithInputBundle(b.GetBundleHash(), target.GetPath(), repoUrl, b.GetLimitToFiles()),
+		testApiV20241221.WithScanType(testModelsV20241221.Scan(a.testType)),
+		testApiV20241221.WithProjectName(reportingConfig.ProjectName),
+		testApiV20241221.WithTargetName(reportingConfig.TargetName),
+		testApiV20241221.WithTargetReference(reportingConfig.TargetReference),
+		testApiV20241221.WithReporting(&reportingConfig.Report),
+	)
+}
+
+func (a *analysisOrchestrator) newRemoteTestBodyV20241221(cfg AnalysisConfig) (*testApiV20241221.CreateTestApplicationVndAPIPlusJSONRequestBody, error) {
+	if cfg.ProjectId == nil || cfg.CommitId == nil {
+		return nil, errors.New("projectId and commitId are required")
+	}
+
+	a.warnDroppedOptionsV20241221(unsupportedOptionsV20241221(cfg))
+
+	legacyScmProject := testApiV20241221.NewTestInputLegacyScmProject(*cfg.ProjectId, *cfg.CommitId)
+	return testApiV20241221.NewCreateTestApplicationBody(
+		testApiV20241221.WithInputLegacyScmProject(legacyScmProject),
+		testApiV20241221.WithReporting(&cfg.Report),
+		testApiV20241221.WithScanType(testModelsV20241221.Scan(a.testType)),
+		testApiV20241221.WithProjectId(*cfg.ProjectId),
+	), nil
+}
+
+func (a *analysisOrchestrator) createTestAndGetResultsV20241221(ctx context.Context, orgId string, body *testApiV20241221.CreateTestApplicationVndAPIPlusJSONRequestBody, progressString string, cfg AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	tracker := a.trackerFactory.GenerateTracker()
+	tracker.Begin(progressString, "Retrieving results...")
+
+	innerFunction := func() (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+		orgUuid, err := uuid.Parse(orgId)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid orgId")
+		}
+
+		client, err := a.newTestClientV20241221()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		testId, err := a.createTestV20241221(ctx, client, orgUuid, body)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.OnTestCreated != nil {
+			cfg.OnTestCreated(testId.String())
+		}
+
+		return a.pollTestForFindingsV20241221(ctx, client, orgUuid, testId, cfg.StreamHandler)
+	}
+
+	result, metadata, err := innerFunction()
+	if err != nil {
+		tracker.End("Analysis failed.")
+	} else {
+		tracker.End("Analysis completed.")
+	}
+
+	return result, metadata, err
+}
+
+func (a *analysisOrchestrator) createTestV20241221(ctx context.Context, client *testApiV20241221.Client, orgUuid uuid.UUID, body *testApiV20241221.CreateTestApplicationVndAPIPlusJSONRequestBody) (openapi_types.UUID, error) {
+	params := testApiV20241221.CreateTestParams{Version: testApiV20241221.ApiVersion}
+
+	resp, err := client.CreateTestWithApplicationVndAPIPlusJSONBody(ctx, orgUuid, &params, *body)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	parsedResponse, err := testApiV20241221.ParseCreateTestResponse(resp)
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			a.logger.Err(closeErr).Msg("failed to close response body")
+		}
+	}()
+	if err != nil {
+		a.logger.Debug().Msg(err.Error())
+		return uuid.Nil, err
+	}
+	if err = a.checkApiVersionHeaders(resp, testApiV20241221.ApiVersion); err != nil {
+		return uuid.Nil, err
+	}
+
+	if parsedResponse.StatusCode() != http.StatusCreated || parsedResponse.ApplicationvndApiJSON201 == nil {
+		return uuid.Nil, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
+	}
+	return parsedResponse.ApplicationvndApiJSON201.Data.Id, nil
+}
+
+func (a *analysisOrchestrator) pollTestForFindingsV20241221(ctx context.Context, client *testApiV20241221.Client, org uuid.UUID, testId openapi_types.UUID, streamHandler *sarif.StreamHandler) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	var resultMetaData *scan.ResultMetaData
+	err := a.pollUntilComplete(ctx, testId, func() (bool, time.Duration, error) {
+		var state testState
+		var err error
+		state, resultMetaData, err = a.retrieveTestStateV20241221(ctx, client, org, testId)
+		if state.retryable {
+			return false, state.retryAfter, nil
+		}
+		return state.status == scan.TestStatusCompleted, state.retryAfter, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultMetaData.TestId = testId.String()
+	if a.summaryOnly {
+		return nil, resultMetaData, nil
+	}
+	findings, err := a.retrieveFindings(ctx, testId, resultMetaData.FindingsUrl, streamHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+	return findings, resultMetaData, nil
+}
+
+// retrieveTestStateV20241221 retrieves the state of a test. Once the test has completed, the returned result metadata
+// points to its findings document.
+func (a *analysisOrchestrator) retrieveTestStateV20241221(ctx context.Context, client *testApiV20241221.Client, org uuid.UUID, testId openapi_types.UUID) (testState, *scan.ResultMetaData, error) {
+	method := "analysis.retrieveTestStateV20241221"
+	logger := a.logger.With().Str("method", method).Logger()
+	logger.Debug().Msg("retrieving Test state")
+
+	httpResponse, err := client.GetTestResult(
+		ctx,
+		org,
+		testId,
+		&testApiV20241221.GetTestResultParams{Version: testApiV20241221.ApiVersion},
+	)
+	if err != nil {
+		logger.Err(err).Str("testId", testId.String()).Msg("error requesting the ScanJobResult")
+		return testState{}, nil, err
+	}
+	defer func() {
+		closeErr := httpResponse.Body.Close()
+		if closeErr != nil {
+			a.logger.Err(closeErr).Msg("failed to close response body")
+		}
+	}()
+
+	parsedResponse, err := testApiV20241221.ParseGetTestResultResponse(httpResponse)
+	if err != nil {
+		return testState{}, nil, err
+	}
+	retryAfter := codeClientHTTP.RetryAfter(httpResponse)
+	if err = a.checkApiVersionHeaders(httpResponse, testApiV20241221.ApiVersion); err != nil {
+		return testState{}, nil, err
+	}
+
+	if retryableStatusCodes[parsedResponse.StatusCode()] {
+		logger.Debug().Int("statusCode", parsedResponse.StatusCode()).Dur("retryAfter", retryAfter).Msg("test state is not available yet")
+		return testState{retryAfter: retryAfter, retryable: true}, nil, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
+	}
+	if parsedResponse.StatusCode() != http.StatusOK || parsedResponse.ApplicationvndApiJSON200 == nil {
+		return testState{}, nil, testApi.NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
+	}
+
+	attributes := parsedResponse.ApplicationvndApiJSON200.Data.Attributes
+	stateDiscriminator, err := attributes.Discriminator()
+	if err != nil {
+		return testState{}, nil, err
+	}
+
+	switch stateDiscriminator {
+	case string(testModelsV20241221.TestAcceptedStateStatusAccepted):
+		return testState{status: scan.TestStatusAccepted, retryAfter: retryAfter}, nil, nil
+	case string(testModelsV20241221.TestInProgressStateStatusInProgress):
+		return testState{status: scan.TestStatusInProgress, retryAfter: retryAfter}, nil, nil
+	case string(testModelsV20241221.TestCompletedStateStatusCompleted):
+		completedState, stateErr := attributes.AsTestCompletedState()
+		if stateErr != nil {
+			return testState{}, nil, stateErr
+		}
+		state := testState{
+			status:    scan.TestStatusCompleted,
+			result:    scan.TestResult(completedState.Results.Outcome.Result),
+			createdAt: completedState.CreatedAt,
+		}
+		return state, a.newResultMetaDataV20241221(completedState, state), nil
+	case string(testModelsV20241221.TestErrorStateStatusError):
+		errorState, stateErr := attributes.AsTestErrorState()
+		if stateErr != nil {
+			return testState{}, nil, stateErr
+		}
+		var testError error
+		if errorState.Errors != nil {
+			for _, stateError := range *errorState.Errors {
+				testError = goerrors.Join(testError, newTestStateError(parsedResponse.StatusCode(), stateError.Classification, stateError.ErrorCode, stateError.Title, stateError.Message, stateError.InfoUrl))
+			}
+		}
+		if testError == nil {
+			testError = fmt.Errorf("%s: test error state has no errors", method)
+		}
+		return testState{status: scan.TestStatusError}, nil, testError
+	default:
+		return testState{}, nil, fmt.Errorf("unexpected test status \"%s\"", stateDiscriminator)
+	}
+}
+
+func (a *analysisOrchestrator) newResultMetaDataV20241221(completedState testModelsV20241221.TestCompletedState, state testState) *scan.ResultMetaData {
+	resultMetaData := &scan.ResultMetaData{
+		FindingsUrl: a.host(true) + completedState.Documents.EnrichedSarif + "?version=" + testApiV20241221.DocumentApiVersion,
+		TestResult:  state.result,
+		CreatedAt:   state.createdAt,
+	}
+
+	webUi := completedState.Results.Webui
+	if webUi != nil {
+		if webUi.Link != nil {
+			resultMetaData.WebUiUrl = *webUi.Link
+		}
+		if webUi.ProjectId != nil {
+			resultMetaData.ProjectId = webUi.ProjectId.String()
+		}
+		if webUi.SnapshotId != nil {
+			resultMetaData.SnapshotId = webUi.SnapshotId.String()
+		}
+	}
+	return resultMetaData
+}
@@ -0,0 +1,165 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	testApiV20241221 "github.com/snyk/code-client-go/internal/api/test/2024-12-21"
+	testApi "github.com/snyk/code-client-go/internal/api/test/2025-04-07"
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+const (
+	// ApiVersion20250407 is the default version of the test API.
+	ApiVersion20250407 = testApi.ApiVersion
+	// ApiVersion20241221 is the oldest supported version of the test API. It only supports testing bundles and
+	// legacy SCM projects.
+	ApiVersion20241221 = testApiV20241221.ApiVersion
+)
+
+// SupportedApiVersions lists the versions of the test API the orchestrator can use, newest first. When a version has
+// been sunset, the orchestrator falls back to the next one in the list.
+var SupportedApiVersions = []string{ApiVersion20250407, ApiVersion20241221}
+
+var errApiVersionSunset = goerrors.New("test API version has been sunset")
+
+// errTestNotCreated marks errors of the request that creates a test. Only then it is safe to run the test again with
+// an older version of the test API, as no test exists yet.
+var errTestNotCreated = goerrors.New("test was not created")
+
+// WithApiVersion selects the version of the test API. It defaults to ApiVersion20250407.
+//
+// Once the orchestrator has fallen back to ApiVersion20241221, the operations that version does not support, i.e.
+// CreateTest, CreateTestRemote, ResumeTest, GetTestStatus, GetTestConfiguration and the RunTest variants other than
+// RunTest and RunTestRemote, fail for the rest of the lifetime of the orchestrator.
+func WithApiVersion(version string) func(*analysisOrchestrator) {
+	return func(a *analysisOrchestrator) {
+		if version != "" {
+			a.apiVersion = version
+		}
+	}
+}
+
+type testResultsFunc func() (*sarif.SarifResponse, *scan.ResultMetaData, error)
+
+// currentApiVersion returns the version of the test API that requests are sent with. It changes when the configured
+// version has been sunset and the orchestrator fell back to an older one.
+func (a *analysisOrchestrator) currentApiVersion() (string, error) {
+	a.apiVersionMutex.Lock()
+	defer a.apiVersionMutex.Unlock()
+
+	if !slices.Contains(SupportedApiVersions, a.apiVersion) {
+		return "", fmt.Errorf("unsupported test API version \"%s\", supported versions are %v", a.apiVersion, SupportedApiVersions)
+	}
+	return a.apiVersion, nil
+}
+
+// requireApiVersion fails if the current version of the test API is not the given one. It guards the operations
+// that older versions of the test API do not support, including after a fallback to an older version.
+func (a *analysisOrchestrator) requireApiVersion(method string, version string) error {
+	current, err := a.currentApiVersion()
+	if err != nil {
+		return err
+	}
+	if current != version {
+		return fmt.Errorf("%s is not supported by test API version %s", method, current)
+	}
+	return nil
+}
+
+// runWithApiVersion runs the test with the current version of the test API. If the test could not be created because
+// the version has been sunset, it falls back to the next supported version and keeps using it for later tests. A
+// version that is sunset while the test is already running fails the test, as running it again would create a
+// second test.
+func (a *analysisOrchestrator) runWithApiVersion(run testResultsFunc, runV20241221 testResultsFunc) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	version, err := a.currentApiVersion()
+	if err != nil {
+		return nil, nil, err
+	}
+	if version == ApiVersion20241221 {
+		return runV20241221()
+	}
+
+	findings, resultMetaData, err := run()
+	if !goerrors.Is(err, errApiVersionSunset) || !goerrors.Is(err, errTestNotCreated) {
+		return findings, resultMetaData, err
+	}
+
+	fallbackVersion := a.fallBackFrom(version)
+	if fallbackVersion != ApiVersion20241221 {
+		return nil, nil, err
+	}
+	a.logger.Warn().Str("apiVersion", version).Str("fallbackApiVersion", fallbackVersion).Msg("test API version has been sunset, falling back to an older version")
+	return runV20241221()
+}
+
+// fallBackFrom switches to the version of the test API that follows the given one and returns the version that is
+// used afterwards.
+func (a *analysisOrchestrator) fallBackFrom(version string) string {
+	a.apiVersionMutex.Lock()
+	defer a.apiVersionMutex.Unlock()
+
+	if a.apiVersion != version {
+		// another test already fell back
+		return a.apiVersion
+	}
+	index := slices.Index(SupportedApiVersions, version)
+	if index >= 0 && index+1 < len(SupportedApiVersions) {
+		a.apiVersion = SupportedApiVersions[index+1]
+	}
+	return a.apiVersion
+}
+
+// checkApiVersionHeaders logs a warning the first time the test API reports the used version as deprecated. It
+// returns errApiVersionSunset if the request failed because the version is no longer served.
+func (a *analysisOrchestrator) checkApiVersionHeaders(rsp *http.Response, version string) error {
+	if rsp == nil {
+		return nil
+	}
+
+	sunset := rsp.Header.Get("Sunset")
+	if rsp.Header.Get("Deprecation") != "" {
+		if _, warned := a.deprecationWarnings.LoadOrStore(version, true); !warned {
+			a.logger.Warn().Str("apiVersion", version).Str("sunset", sunset).Msg("test API version is deprecated, please upgrade")
+		}
+	}
+
+	if rsp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	if rsp.StatusCode == http.StatusGone || isSunsetPassed(sunset) {
+		return fmt.Errorf("%w: %s", errApiVersionSunset, version)
+	}
+	return nil
+}
+
+func isSunsetPassed(sunset string) bool {
+	if sunset == "" {
+		return false
+	}
+	sunsetTime, err := http.ParseTime(sunset)
+	if err != nil {
+		return false
+	}
+	return !time.Now().Before(sunsetTime)
+}
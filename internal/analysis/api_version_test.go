@@ -0,0 +1,354 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mocks2 "github.com/snyk/code-client-go/bundle/mocks"
+	httpmocks "github.com/snyk/code-client-go/http/mocks"
+	"github.com/snyk/code-client-go/internal/analysis"
+	v20241221 "github.com/snyk/code-client-go/internal/api/test/2024-12-21"
+	v20250407 "github.com/snyk/code-client-go/internal/api/test/2025-04-07"
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+func mockApiResponse(t *testing.T, mockHTTPClient *httpmocks.MockHTTPClient, method string, url string, statusCode int, header http.Header, body string) {
+	t.Helper()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/vnd.api+json")
+	mockHTTPClient.EXPECT().Do(mock.MatchedBy(func(i interface{}) bool {
+		req := i.(*http.Request)
+		return req.URL.String() == url && req.Method == method
+	})).Times(1).Return(&http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil)
+}
+
+func mockV20241221TestCreatedResponse(t *testing.T, mockHTTPClient *httpmocks.MockHTTPClient, orgId string, testId uuid.UUID) {
+	t.Helper()
+	url := fmt.Sprintf("http://localhost/hidden/orgs/%s/tests?version=%s", orgId, v20241221.ApiVersion)
+	body := fmt.Sprintf(`{"data": {"id": "%s", "type": "test"}, "jsonapi": {"version": "1.0"}, "links": {}}`, testId)
+	mockApiResponse(t, mockHTTPClient, http.MethodPost, url, http.StatusCreated, nil, body)
+}
+
+func mockV20241221TestCompletedResponse(t *testing.T, mockHTTPClient *httpmocks.MockHTTPClient, orgId string, testId uuid.UUID, documentPath string, projectId uuid.UUID, snapshotId uuid.UUID) {
+	t.Helper()
+	url := fmt.Sprintf("http://localhost/hidden/orgs/%s/tests/%s?version=%s", orgId, testId, v20241221.ApiVersion)
+	body := fmt.Sprintf(`{
+		"data": {"id": "%s", "type": "test", "attributes": {
+			"status": "completed",
+			"created_at": "%s",
+			"documents": {"enriched_sarif": "%s"},
+			"results": {"outcome": {"result": "failed"}, "webui": {"link": "https://app.snyk.io/project", "project_id": "%s", "snapshot_id": "%s"}}
+		}},
+		"jsonapi": {"version": "1.0"},
+		"links": {}
+	}`, testId, time.Now().Format(time.RFC3339), documentPath, projectId, snapshotId)
+	mockApiResponse(t, mockHTTPClient, http.MethodGet, url, http.StatusOK, nil, body)
+}
+
+func mockV20241221GetSarifResponse(t *testing.T, mockHTTPClient *httpmocks.MockHTTPClient, documentPath string, document sarif.SarifDocument) {
+	t.Helper()
+	responseBodyBytes, err := json.Marshal(document)
+	require.NoError(t, err)
+	url := fmt.Sprintf("http://localhost/hidden%s?version=%s", documentPath, v20241221.DocumentApiVersion)
+	mockHTTPClient.EXPECT().Do(mock.MatchedBy(func(i interface{}) bool {
+		req := i.(*http.Request)
+		return req.URL.String() == url && req.Method == http.MethodGet
+	})).Times(1).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(responseBodyBytes)),
+	}, nil)
+}
+
+func TestAnalysis_RunTest_ApiVersion20241221(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, mockTracker, mockTrackerFactory, logger := setup(t, nil)
+	mockTracker.EXPECT().Begin(gomock.Eq("Snyk Code analysis for ../mypath/"), gomock.Eq("Retrieving results...")).Return()
+	mockTracker.EXPECT().End(gomock.Eq("Analysis completed.")).Return()
+
+	orgId := "4a72d1db-b465-4764-99e1-ecedad03b06a"
+	testId := uuid.New()
+	projectId := uuid.New()
+	snapshotId := uuid.New()
+	inputBundle := mocks2.NewMockBundle(ctrl)
+	inputBundle.EXPECT().GetBundleHash().Return("bundle-hash").AnyTimes()
+	inputBundle.EXPECT().GetLimitToFiles().Return([]string{}).AnyTimes()
+	target, err := scan.NewRepositoryTarget("../mypath/")
+	require.NoError(t, err)
+
+	mockV20241221TestCreatedResponse(t, mockHTTPClient, orgId, testId)
+	mockV20241221TestCompletedResponse(t, mockHTTPClient, orgId, testId, "/sarif", projectId, snapshotId)
+	mockV20241221GetSarifResponse(t, mockHTTPClient, "/sarif", sarif.SarifDocument{Version: "2.1.0"})
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+		analysis.WithApiVersion(analysis.ApiVersion20241221),
+	)
+
+	result, resultMetadata, err := analysisOrchestrator.RunTest(t.Context(), orgId, inputBundle, target, analysis.AnalysisConfig{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2.1.0", result.Sarif.Version)
+	assert.Equal(t, testId.String(), resultMetadata.TestId)
+	assert.Equal(t, "https://app.snyk.io/project", resultMetadata.WebUiUrl)
+	assert.Equal(t, projectId.String(), resultMetadata.ProjectId)
+	assert.Equal(t, snapshotId.String(), resultMetadata.SnapshotId)
+	assert.Equal(t, scan.TestResultFailed, resultMetadata.TestResult)
+	assert.Empty(t, resultMetadata.Components)
+}
+
+func TestAnalysis_RunTest_ApiVersion20241221_WarnsAboutDroppedOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, mockTracker, mockTrackerFactory, _ := setup(t, nil)
+	mockTracker.EXPECT().Begin(gomock.Any(), gomock.Any()).Return()
+	mockTracker.EXPECT().End(gomock.Eq("Analysis completed.")).Return()
+
+	orgId := "4a72d1db-b465-4764-99e1-ecedad03b06a"
+	testId := uuid.New()
+	var logOutput bytes.Buffer
+	logger := zerolog.New(&logOutput)
+	inputBundle := mocks2.NewMockBundle(ctrl)
+	inputBundle.EXPECT().GetBundleHash().Return("bundle-hash").AnyTimes()
+	inputBundle.EXPECT().GetLimitToFiles().Return([]string{}).AnyTimes()
+	target, err := scan.NewRepositoryTarget("../mypath/", scan.WithCommitId("abc123"))
+	require.NoError(t, err)
+
+	mockV20241221TestCreatedResponse(t, mockHTTPClient, orgId, testId)
+	mockV20241221TestCompletedResponse(t, mockHTTPClient, orgId, testId, "/sarif", uuid.New(), uuid.New())
+	mockV20241221GetSarifResponse(t, mockHTTPClient, "/sarif", sarif.SarifDocument{Version: "2.1.0"})
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+		analysis.WithApiVersion(analysis.ApiVersion20241221),
+	)
+
+	label := "nightly"
+	projectTags := []string{"team=appsec"}
+	_, _, err = analysisOrchestrator.RunTest(t.Context(), orgId, inputBundle, target, analysis.AnalysisConfig{
+		Label:       &label,
+		ProjectTags: &projectTags,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, logOutput.String(), `"droppedOptions":["label","projectTags","commitId"`)
+}
+
+func TestAnalysis_RunTestRemote_FallsBackWhenApiVersionIsSunset(t *testing.T) {
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, mockTracker, mockTrackerFactory, logger := setup(t, nil)
+	mockTracker.EXPECT().Begin(gomock.Eq("Snyk Code analysis for remote project"), gomock.Eq("Retrieving results...")).Return().Times(2)
+	mockTracker.EXPECT().End(gomock.Eq("Analysis failed.")).Return()
+	mockTracker.EXPECT().End(gomock.Eq("Analysis completed.")).Return()
+
+	orgId := "4a72d1db-b465-4764-99e1-ecedad03b06a"
+	testId := uuid.New()
+	projectId := uuid.New()
+	snapshotId := uuid.New()
+	commitId := "abc123"
+
+	sunsetUrl := fmt.Sprintf("http://localhost/hidden/orgs/%s/tests?version=%s", orgId, v20250407.ApiVersion)
+	mockApiResponse(t, mockHTTPClient, http.MethodPost, sunsetUrl, http.StatusGone, http.Header{
+		"Deprecation": []string{"@1735689600"},
+		"Sunset":      []string{"Wed, 01 Jan 2025 00:00:00 GMT"},
+	}, `{"errors": [{"status": "410", "detail": "version sunset"}], "jsonapi": {"version": "1.0"}}`)
+	mockV20241221TestCreatedResponse(t, mockHTTPClient, orgId, testId)
+	mockV20241221TestCompletedResponse(t, mockHTTPClient, orgId, testId, "/sarif", projectId, snapshotId)
+	mockV20241221GetSarifResponse(t, mockHTTPClient, "/sarif", sarif.SarifDocument{Version: "2.1.0"})
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+	)
+
+	result, resultMetadata, err := analysisOrchestrator.RunTestRemote(t.Context(), orgId, analysis.AnalysisConfig{
+		ProjectId: &projectId,
+		CommitId:  &commitId,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2.1.0", result.Sarif.Version)
+	assert.Equal(t, testId.String(), resultMetadata.TestId)
+
+	// the negotiated version is kept for subsequent requests
+	_, err = analysisOrchestrator.CreateTestRemote(t.Context(), orgId, analysis.AnalysisConfig{
+		ProjectId: &projectId,
+		CommitId:  &commitId,
+	})
+	assert.ErrorContains(t, err, "not supported by test API version "+v20241221.ApiVersion)
+}
+
+func TestAnalysis_RunTestRemote_DoesNotFallBackOnceTheTestIsCreated(t *testing.T) {
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, mockTracker, mockTrackerFactory, logger := setup(t, nil)
+	mockTracker.EXPECT().Begin(gomock.Eq("Snyk Code analysis for remote project"), gomock.Eq("Retrieving results...")).Return()
+	mockTracker.EXPECT().End(gomock.Eq("Analysis failed.")).Return()
+
+	orgId := "4a72d1db-b465-4764-99e1-ecedad03b06a"
+	testId := uuid.New()
+	projectId := uuid.New()
+	commitId := "abc123"
+
+	mockTestCreatedResponse(t, mockHTTPClient, testId, orgId, http.StatusCreated)
+	statusUrl := fmt.Sprintf("http://localhost/hidden/orgs/%s/tests/%s?version=%s", orgId, testId, v20250407.ApiVersion)
+	mockApiResponse(t, mockHTTPClient, http.MethodGet, statusUrl, http.StatusGone, nil,
+		`{"errors": [{"status": "410", "detail": "version sunset"}], "jsonapi": {"version": "1.0"}}`)
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+	)
+
+	createdTests := 0
+	_, _, err := analysisOrchestrator.RunTestRemote(t.Context(), orgId, analysis.AnalysisConfig{
+		ProjectId:     &projectId,
+		CommitId:      &commitId,
+		OnTestCreated: func(string) { createdTests++ },
+	})
+
+	assert.ErrorContains(t, err, "test API version has been sunset")
+	assert.Equal(t, 1, createdTests)
+}
+
+func TestAnalysis_GetTestConfiguration_WarnsAboutDeprecatedApiVersion(t *testing.T) {
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, _, mockTrackerFactory, _ := setup(t, nil)
+
+	orgId := "4a72d1db-b465-4764-99e1-ecedad03b06a"
+	testId := uuid.New()
+	var logOutput bytes.Buffer
+	logger := zerolog.New(&logOutput)
+
+	url := fmt.Sprintf("http://localhost/hidden/orgs/%s/tests/%s/configuration?version=%s", orgId, testId, v20250407.ApiVersion)
+	mockApiResponse(t, mockHTTPClient, http.MethodGet, url, http.StatusOK, http.Header{
+		"Deprecation": []string{"@1735689600"},
+		"Sunset":      []string{"Fri, 01 Jan 2100 00:00:00 GMT"},
+	}, `{"data": {"type": "test", "attributes": {}}, "jsonapi": {"version": "1.0"}}`)
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+	)
+
+	_, err := analysisOrchestrator.GetTestConfiguration(t.Context(), orgId, testId.String())
+
+	require.NoError(t, err)
+	assert.Contains(t, logOutput.String(), "test API version is deprecated")
+}
+
+func TestAnalysis_GetTestStatus_WarnsOnceAboutDeprecatedApiVersion(t *testing.T) {
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, _, mockTrackerFactory, _ := setup(t, nil)
+
+	orgId := "4a72d1db-b465-4764-99e1-ecedad03b06a"
+	testId := uuid.New()
+	var logOutput bytes.Buffer
+	logger := zerolog.New(&logOutput)
+
+	url := fmt.Sprintf("http://localhost/hidden/orgs/%s/tests/%s?version=%s", orgId, testId, v20250407.ApiVersion)
+	body := fmt.Sprintf(`{
+		"data": {"id": "%s", "type": "test", "attributes": {"status": "in_progress", "created_at": "%s"}},
+		"jsonapi": {"version": "1.0"},
+		"links": {}
+	}`, testId, time.Now().Format(time.RFC3339))
+	for range 2 {
+		mockApiResponse(t, mockHTTPClient, http.MethodGet, url, http.StatusOK, http.Header{
+			"Deprecation": []string{"@1735689600"},
+			"Sunset":      []string{"Fri, 01 Jan 2100 00:00:00 GMT"},
+		}, body)
+	}
+
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+	)
+
+	for range 2 {
+		status, err := analysisOrchestrator.GetTestStatus(t.Context(), orgId, testId.String())
+		require.NoError(t, err)
+		assert.Equal(t, scan.TestStatusInProgress, status)
+	}
+
+	assert.Equal(t, 1, strings.Count(logOutput.String(), "test API version is deprecated"))
+	assert.Contains(t, logOutput.String(), "Fri, 01 Jan 2100 00:00:00 GMT")
+}
+
+func TestAnalysis_RunTestRemote_UnsupportedApiVersion(t *testing.T) {
+	mockConfig, mockHTTPClient, mockInstrumentor, mockErrorReporter, _, mockTrackerFactory, logger := setup(t, nil)
+
+	projectId := uuid.New()
+	commitId := "abc123"
+	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
+		mockConfig,
+		mockHTTPClient,
+		analysis.WithLogger(&logger),
+		analysis.WithInstrumentor(mockInstrumentor),
+		analysis.WithTrackerFactory(mockTrackerFactory),
+		analysis.WithErrorReporter(mockErrorReporter),
+		analysis.WithApiVersion("2020-01-01"),
+	)
+
+	_, _, err := analysisOrchestrator.RunTestRemote(t.Context(), "4a72d1db-b465-4764-99e1-ecedad03b06a", analysis.AnalysisConfig{
+		ProjectId: &projectId,
+		CommitId:  &commitId,
+	})
+
+	assert.ErrorContains(t, err, "unsupported test API version \"2020-01-01\"")
+}
@@ -38,10 +38,86 @@ func (m *MockAnalysisOrchestrator) EXPECT() *MockAnalysisOrchestratorMockRecorde
 	return m.recorder
 }
 
+// CreateTest mocks base method.
+func (m *MockAnalysisOrchestrator) CreateTest(ctx context.Context, orgId string, b bundle.Bundle, target scan.Target, reportingOptions analysis.AnalysisConfig) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTest", ctx, orgId, b, target, reportingOptions)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTest indicates an expected call of CreateTest.
+func (mr *MockAnalysisOrchestratorMockRecorder) CreateTest(ctx, orgId, b, target, reportingOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTest", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).CreateTest), ctx, orgId, b, target, reportingOptions)
+}
+
+// CreateTestRemote mocks base method.
+func (m *MockAnalysisOrchestrator) CreateTestRemote(ctx context.Context, orgId string, reportingOptions analysis.AnalysisConfig) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTestRemote", ctx, orgId, reportingOptions)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTestRemote indicates an expected call of CreateTestRemote.
+func (mr *MockAnalysisOrchestratorMockRecorder) CreateTestRemote(ctx, orgId, reportingOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTestRemote", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).CreateTestRemote), ctx, orgId, reportingOptions)
+}
+
+// GetTestConfiguration mocks base method.
+func (m *MockAnalysisOrchestrator) GetTestConfiguration(ctx context.Context, orgId, testId string) (*scan.TestConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestConfiguration", ctx, orgId, testId)
+	ret0, _ := ret[0].(*scan.TestConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestConfiguration indicates an expected call of GetTestConfiguration.
+func (mr *MockAnalysisOrchestratorMockRecorder) GetTestConfiguration(ctx, orgId, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestConfiguration", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).GetTestConfiguration), ctx, orgId, testId)
+}
+
+// GetTestStatus mocks base method.
+func (m *MockAnalysisOrchestrator) GetTestStatus(ctx context.Context, orgId, testId string) (scan.TestStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestStatus", ctx, orgId, testId)
+	ret0, _ := ret[0].(scan.TestStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestStatus indicates an expected call of GetTestStatus.
+func (mr *MockAnalysisOrchestratorMockRecorder) GetTestStatus(ctx, orgId, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestStatus", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).GetTestStatus), ctx, orgId, testId)
+}
+
+// ResumeTest mocks base method.
+func (m *MockAnalysisOrchestrator) ResumeTest(ctx context.Context, orgId, testId string) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeTest", ctx, orgId, testId)
+	ret0, _ := ret[0].(*sarif.SarifResponse)
+	ret1, _ := ret[1].(*scan.ResultMetaData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResumeTest indicates an expected call of ResumeTest.
+func (mr *MockAnalysisOrchestratorMockRecorder) ResumeTest(ctx, orgId, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTest", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).ResumeTest), ctx, orgId, testId)
+}
+
 // RunLegacyTest mocks base method.
-func (m *MockAnalysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash, shardKey string, limitToFiles []string, severity int) (*sarif.SarifResponse, scan.LegacyScanStatus, error) {
+func (m *MockAnalysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash, shardKey string, limitToFiles []string, severity int, prioritized bool) (*sarif.SarifResponse, scan.LegacyScanStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RunLegacyTest", ctx, bundleHash, shardKey, limitToFiles, severity)
+	ret := m.ctrl.Call(m, "RunLegacyTest", ctx, bundleHash, shardKey, limitToFiles, severity, prioritized)
 	ret0, _ := ret[0].(*sarif.SarifResponse)
 	ret1, _ := ret[1].(scan.LegacyScanStatus)
 	ret2, _ := ret[2].(error)
@@ -49,9 +125,9 @@ func (m *MockAnalysisOrchestrator) RunLegacyTest(ctx context.Context, bundleHash
 }
 
 // RunLegacyTest indicates an expected call of RunLegacyTest.
-func (mr *MockAnalysisOrchestratorMockRecorder) RunLegacyTest(ctx, bundleHash, shardKey, limitToFiles, severity interface{}) *gomock.Call {
+func (mr *MockAnalysisOrchestratorMockRecorder) RunLegacyTest(ctx, bundleHash, shardKey, limitToFiles, severity, prioritized interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunLegacyTest", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunLegacyTest), ctx, bundleHash, shardKey, limitToFiles, severity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunLegacyTest", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunLegacyTest), ctx, bundleHash, shardKey, limitToFiles, severity, prioritized)
 }
 
 // RunTest mocks base method.
@@ -70,6 +146,38 @@ func (mr *MockAnalysisOrchestratorMockRecorder) RunTest(ctx, orgId, b, target, r
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTest", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunTest), ctx, orgId, b, target, reportingOptions)
 }
 
+// RunTestDiff mocks base method.
+func (m *MockAnalysisOrchestrator) RunTestDiff(ctx context.Context, orgId string, reportingOptions analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTestDiff", ctx, orgId, reportingOptions)
+	ret0, _ := ret[0].(*sarif.SarifResponse)
+	ret1, _ := ret[1].(*scan.ResultMetaData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunTestDiff indicates an expected call of RunTestDiff.
+func (mr *MockAnalysisOrchestratorMockRecorder) RunTestDiff(ctx, orgId, reportingOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTestDiff", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunTestDiff), ctx, orgId, reportingOptions)
+}
+
+// RunTestGitUrlCoordinates mocks base method.
+func (m *MockAnalysisOrchestrator) RunTestGitUrlCoordinates(ctx context.Context, orgId string, reportingOptions analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTestGitUrlCoordinates", ctx, orgId, reportingOptions)
+	ret0, _ := ret[0].(*sarif.SarifResponse)
+	ret1, _ := ret[1].(*scan.ResultMetaData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunTestGitUrlCoordinates indicates an expected call of RunTestGitUrlCoordinates.
+func (mr *MockAnalysisOrchestratorMockRecorder) RunTestGitUrlCoordinates(ctx, orgId, reportingOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTestGitUrlCoordinates", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunTestGitUrlCoordinates), ctx, orgId, reportingOptions)
+}
+
 // RunTestRemote mocks base method.
 func (m *MockAnalysisOrchestrator) RunTestRemote(ctx context.Context, orgId string, reportingOptions analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
 	m.ctrl.T.Helper()
@@ -85,3 +193,35 @@ func (mr *MockAnalysisOrchestratorMockRecorder) RunTestRemote(ctx, orgId, report
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTestRemote", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunTestRemote), ctx, orgId, reportingOptions)
 }
+
+// RunTestSbom mocks base method.
+func (m *MockAnalysisOrchestrator) RunTestSbom(ctx context.Context, orgId string, sourceBundle, sbomBundle bundle.Bundle, target scan.Target, sbomFilePath string, reportingOptions analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTestSbom", ctx, orgId, sourceBundle, sbomBundle, target, sbomFilePath, reportingOptions)
+	ret0, _ := ret[0].(*sarif.SarifResponse)
+	ret1, _ := ret[1].(*scan.ResultMetaData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunTestSbom indicates an expected call of RunTestSbom.
+func (mr *MockAnalysisOrchestratorMockRecorder) RunTestSbom(ctx, orgId, sourceBundle, sbomBundle, target, sbomFilePath, reportingOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTestSbom", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunTestSbom), ctx, orgId, sourceBundle, sbomBundle, target, sbomFilePath, reportingOptions)
+}
+
+// RunTestWorkspace mocks base method.
+func (m *MockAnalysisOrchestrator) RunTestWorkspace(ctx context.Context, orgId string, reportingOptions analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTestWorkspace", ctx, orgId, reportingOptions)
+	ret0, _ := ret[0].(*sarif.SarifResponse)
+	ret1, _ := ret[1].(*scan.ResultMetaData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunTestWorkspace indicates an expected call of RunTestWorkspace.
+func (mr *MockAnalysisOrchestratorMockRecorder) RunTestWorkspace(ctx, orgId, reportingOptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTestWorkspace", reflect.TypeOf((*MockAnalysisOrchestrator)(nil).RunTestWorkspace), ctx, orgId, reportingOptions)
+}
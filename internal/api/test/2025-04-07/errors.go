@@ -0,0 +1,44 @@
+package v20250407
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+
+	"github.com/snyk/error-catalog-golang-public/cli"
+
+	externalRef0 "github.com/snyk/code-client-go/internal/api/test/2025-04-07/common"
+)
+
+// NewErrorFromResponse converts the JSON:API error document of an unsuccessful response into error catalog errors.
+// If the body is not an error document, an error mentioning the status code is returned instead.
+func NewErrorFromResponse(statusCode int, body []byte) error {
+	var errorDocument externalRef0.ErrorDocument
+	if err := json.Unmarshal(body, &errorDocument); err != nil || len(errorDocument.Errors) == 0 {
+		return fmt.Errorf("unexpected response status \"%d\"", statusCode)
+	}
+
+	var result error
+	for _, apiError := range errorDocument.Errors {
+		catalogError := cli.NewGeneralCLIFailureError(apiError.Detail)
+		catalogError.Level = "error"
+		catalogError.StatusCode = statusCode
+		if apiError.Code != nil {
+			catalogError.ErrorCode = *apiError.Code
+		}
+		if apiError.Title != nil {
+			catalogError.Title = *apiError.Title
+		}
+		if apiError.Meta != nil {
+			catalogError.Meta = *apiError.Meta
+		}
+		if apiError.Links != nil {
+			if about, linkErr := LinkHref(apiError.Links.About); linkErr == nil && about != "" {
+				catalogError.Type = about
+				catalogError.Links = []string{about}
+			}
+		}
+		result = goerrors.Join(result, catalogError)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package v20250407
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/snyk/error-catalog-golang-public/snyk_errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewErrorFromResponse(t *testing.T) {
+	t.Run("decodes JSON:API errors into error catalog errors", func(t *testing.T) {
+		body := []byte(`{
+			"jsonapi": {"version": "1.0"},
+			"errors": [
+				{"status": "403", "code": "SNYK-CODE-0001", "title": "Forbidden", "detail": "Snyk Code is not enabled",
+				 "links": {"about": "https://docs.snyk.io/errors/SNYK-CODE-0001"}, "meta": {"org": "my-org"}},
+				{"status": "403", "detail": "second error"}
+			]
+		}`)
+
+		err := NewErrorFromResponse(http.StatusForbidden, body)
+
+		var catalogError snyk_errors.Error
+		require.True(t, errors.As(err, &catalogError))
+		assert.Equal(t, http.StatusForbidden, catalogError.StatusCode)
+		assert.Equal(t, "SNYK-CODE-0001", catalogError.ErrorCode)
+		assert.Equal(t, "Forbidden", catalogError.Title)
+		assert.Equal(t, "Snyk Code is not enabled", catalogError.Detail)
+		assert.Equal(t, "https://docs.snyk.io/errors/SNYK-CODE-0001", catalogError.Type)
+		assert.Equal(t, []string{"https://docs.snyk.io/errors/SNYK-CODE-0001"}, catalogError.Links)
+		assert.Equal(t, "my-org", catalogError.Meta["org"])
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+	})
+
+	t.Run("falls back to the status code", func(t *testing.T) {
+		err := NewErrorFromResponse(http.StatusBadGateway, []byte("<html>bad gateway</html>"))
+
+		assert.EqualError(t, err, "unexpected response status \"502\"")
+	})
+}
@@ -34,17 +34,119 @@ func WithInputBundle(id string, localFilePath string, repoUrl *string, limitTest
 	}
 }
 
+func WithInputUploadRevision(revisionId string, localFilePath string, repoUrl *string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		revisionInput := v20250407.TestInputUploadRevision{
+			RevisionId: revisionId,
+			Type:       v20250407.UploadRevision,
+			Metadata: &struct {
+				LocalFilePath *string `json:"local_file_path,omitempty"`
+				RepoUrl       *string `json:"repo_url,omitempty"`
+			}{LocalFilePath: &localFilePath, RepoUrl: repoUrl},
+		}
+
+		body.Data.Attributes.Input.FromTestInputUploadRevision(revisionInput)
+	}
+}
+
+// WithInputSBOMSourceBundles tests an SBOM document together with the source code, each uploaded as a bundle.
+func WithInputSBOMSourceBundles(sbomBundleId string, sbomFilePath string, sourceBundleId string, sourcePath string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		input := v20250407.TestInputSBOMSourceBundles{Type: v20250407.SbomSastBundles}
+		input.Sbom.BundleId = sbomBundleId
+		input.Sbom.Metadata.LocalFilePath = sbomFilePath
+		input.Source.BundleId = sourceBundleId
+		input.Source.Metadata.LocalFilePath = sourcePath
+
+		body.Data.Attributes.Input.FromTestInputSBOMSourceBundles(input)
+	}
+}
+
+// WithInputSBOMSourceRevisions tests an SBOM document together with the source code, each uploaded as an upload
+// revision.
+func WithInputSBOMSourceRevisions(sbomRevisionId openapi_types.UUID, sbomFilePath string, sourceRevisionId openapi_types.UUID, sourcePath string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		input := v20250407.TestInputSBOMSourceRevisions{Type: v20250407.SbomSourceRevisions}
+		input.Sbom.RevisionId = sbomRevisionId
+		input.Sbom.Metadata.LocalFilePath = sbomFilePath
+		input.Source.RevisionId = sourceRevisionId
+		input.Source.Metadata.LocalFilePath = sourcePath
+
+		body.Data.Attributes.Input.FromTestInputSBOMSourceRevisions(input)
+	}
+}
+
 func WithInputLegacyScmProject(project v20250407.TestInputLegacyScmProject) CreateTestOption {
 	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
 		body.Data.Attributes.Input.FromTestInputLegacyScmProject(project)
 	}
 }
 
+func WithInputGitUrlCoordinates(coordinates v20250407.TestInputGitUrlCoordinates) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		body.Data.Attributes.Input.FromTestInputGitUrlCoordinates(coordinates)
+	}
+}
+
+func WithInputDiffTarget(diffTarget v20250407.TestInputDiffTarget) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		body.Data.Attributes.Input.FromTestInputDiffTarget(diffTarget)
+	}
+}
+
+func WithInputWorkspace(workspace v20250407.TestInputWorkspace) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		body.Data.Attributes.Input.FromTestInputWorkspace(workspace)
+	}
+}
+
+func ensureScan(body *CreateTestApplicationVndAPIPlusJSONRequestBody) *v20250407.ScanConfig {
+	if body.Data.Attributes.Configuration.Scan == nil {
+		body.Data.Attributes.Configuration.Scan = &v20250407.ScanConfig{}
+	}
+	return body.Data.Attributes.Configuration.Scan
+}
+
 func WithScanType(t v20250407.ResultType) CreateTestOption {
 	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
-		body.Data.Attributes.Configuration.Scan = &v20250407.ScanConfig{
-			ResultType: &t,
+		scan := ensureScan(body)
+		scan.ResultType = &t
+	}
+}
+
+func WithExclusionGlobs(globs *[]string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if globs == nil {
+			return
+		}
+		scan := ensureScan(body)
+		scan.ExclusionGlobs = globs
+	}
+}
+
+// WithLimitTestToFiles limits the findings of the test to a subset of the files of its input. Bundle inputs carry the
+// files in their metadata instead.
+func WithLimitTestToFiles(files []string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if len(files) == 0 {
+			return
+		}
+		scan := ensureScan(body)
+		scan.LimitTestToFiles = &files
+	}
+}
+
+func WithScanners(scanners *[]string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if scanners == nil {
+			return
+		}
+		scanConfigScanners := make([]v20250407.ScanConfigScanners, 0, len(*scanners))
+		for _, scanner := range *scanners {
+			scanConfigScanners = append(scanConfigScanners, v20250407.ScanConfigScanners(scanner))
 		}
+		scan := ensureScan(body)
+		scan.Scanners = &scanConfigScanners
 	}
 }
 
@@ -102,6 +204,47 @@ func WithTargetReference(targetRef *string) CreateTestOption {
 	}
 }
 
+func WithInitiator(initiator *string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if initiator == nil || len(*initiator) == 0 {
+			return
+		}
+		out := ensureOutput(body)
+		outputInitiator := v20250407.OutputConfigInitiator(*initiator)
+		out.Initiator = &outputInitiator
+	}
+}
+
+func WithLabel(label *string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if label == nil || len(*label) == 0 {
+			return
+		}
+		out := ensureOutput(body)
+		out.Label = label
+	}
+}
+
+func WithLabels(labels *map[string]string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if labels == nil || len(*labels) == 0 {
+			return
+		}
+		out := ensureOutput(body)
+		out.Labels = labels
+	}
+}
+
+func WithOrigin(origin *string) CreateTestOption {
+	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
+		if origin == nil || len(*origin) == 0 {
+			return
+		}
+		out := ensureOutput(body)
+		out.Origin = origin
+	}
+}
+
 func WithReporting(report *bool) CreateTestOption {
 	return func(body *CreateTestApplicationVndAPIPlusJSONRequestBody) {
 		if report == nil {
@@ -133,6 +276,31 @@ func NewTestInputLegacyScmProject(projectId openapi_types.UUID, commitId string)
 	}
 }
 
+func NewTestInputGitUrlCoordinates(integrationId openapi_types.UUID, repoUrl string, revision string) v20250407.TestInputGitUrlCoordinates {
+	return v20250407.TestInputGitUrlCoordinates{
+		IntegrationId: integrationId,
+		RepoUrl:       repoUrl,
+		Revision:      revision,
+		Type:          v20250407.GitUrlCoordinates,
+	}
+}
+
+func NewTestInputDiffTarget(targetId openapi_types.UUID, baseVersion string, headVersion string) v20250407.TestInputDiffTarget {
+	return v20250407.TestInputDiffTarget{
+		TargetId:    targetId,
+		BaseVersion: baseVersion,
+		HeadVersion: headVersion,
+		Type:        v20250407.DiffScmTarget,
+	}
+}
+
+func NewTestInputWorkspace(workspaceId openapi_types.UUID) v20250407.TestInputWorkspace {
+	return v20250407.TestInputWorkspace{
+		WorkspaceId: workspaceId,
+		Type:        v20250407.Workspace,
+	}
+}
+
 func NewTestResponse() *v20250407.TestResult {
 	return &v20250407.TestResult{
 		Data: struct {
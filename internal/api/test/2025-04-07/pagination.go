@@ -0,0 +1,145 @@
+package v20250407
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	externalRef0 "github.com/snyk/code-client-go/internal/api/test/2025-04-07/common"
+	v20250407 "github.com/snyk/code-client-go/internal/api/test/2025-04-07/models"
+)
+
+// ListPage is a single page of a JSON:API list response.
+type ListPage[T any] struct {
+	Data []T
+	Next *externalRef0.LinkProperty
+}
+
+// ParseListPageFunc parses the response of a list endpoint into a page. It is responsible for closing the body.
+type ParseListPageFunc[T any] func(rsp *http.Response) (*ListPage[T], error)
+
+// ListAll requests the first page of a list endpoint, follows the `next` links of the responses and returns the
+// data of all pages.
+func ListAll[T any](ctx context.Context, c *Client, firstPage func(ctx context.Context) (*http.Response, error), parse ParseListPageFunc[T]) ([]T, error) {
+	rsp, err := firstPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []T
+	visited := map[string]bool{}
+	for {
+		page, parseErr := parse(rsp)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		result = append(result, page.Data...)
+
+		href, linkErr := LinkHref(page.Next)
+		if linkErr != nil {
+			return nil, linkErr
+		}
+		if href == "" {
+			return result, nil
+		}
+
+		nextUrl, resolveErr := c.resolveLink(href)
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		if visited[nextUrl] {
+			return nil, fmt.Errorf("pagination loop detected at %q", nextUrl)
+		}
+		visited[nextUrl] = true
+
+		rsp, err = c.getLink(ctx, nextUrl)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// LinkHref returns the URL of a JSON:API link, which is either a plain string or an object with an href. It returns
+// an empty string if there is no link.
+func LinkHref(link *externalRef0.LinkProperty) (string, error) {
+	if link == nil {
+		return "", nil
+	}
+
+	data, err := link.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+
+	if href, stringErr := link.AsLinkProperty0(); stringErr == nil {
+		return href, nil
+	}
+	linkObject, err := link.AsLinkProperty1()
+	if err != nil {
+		return "", err
+	}
+	return linkObject.Href, nil
+}
+
+// resolveLink turns a link of a response into an absolute URL. Relative links are resolved against the server of
+// the client, whether or not they contain the path of the server.
+func (c *Client) resolveLink(href string) (string, error) {
+	linkUrl, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+	if linkUrl.IsAbs() {
+		return linkUrl.String(), nil
+	}
+
+	serverUrl, err := url.Parse(c.Server)
+	if err != nil {
+		return "", err
+	}
+	basePath := strings.TrimSuffix(serverUrl.Path, "/")
+	if !strings.HasPrefix(linkUrl.Path, basePath+"/") {
+		linkUrl.Path = basePath + "/" + strings.TrimPrefix(linkUrl.Path, "/")
+	}
+	return serverUrl.ResolveReference(linkUrl).String(), nil
+}
+
+func (c *Client) getLink(ctx context.Context, link string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.applyEditors(ctx, req, nil); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ParseGetComponentsPage parses a page of the components of a test.
+func ParseGetComponentsPage(rsp *http.Response) (*ListPage[v20250407.GetComponentsResponseItem], error) {
+	parsedResponse, err := ParseGetComponentsResponse(rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsedResponse.ApplicationvndApiJSON200 == nil {
+		return nil, NewErrorFromResponse(parsedResponse.StatusCode(), parsedResponse.Body)
+	}
+	return &ListPage[v20250407.GetComponentsResponseItem]{
+		Data: parsedResponse.ApplicationvndApiJSON200.Data,
+		Next: parsedResponse.ApplicationvndApiJSON200.Links.Next,
+	}, nil
+}
+
+// GetAllComponents retrieves the components of a test from all pages.
+func (c *Client) GetAllComponents(ctx context.Context, orgId openapi_types.UUID, testId openapi_types.UUID, params *GetComponentsParams) ([]v20250407.GetComponentsResponseItem, error) {
+	return ListAll(ctx, c, func(ctx context.Context) (*http.Response, error) {
+		return c.GetComponents(ctx, orgId, testId, params)
+	}, ParseGetComponentsPage)
+}
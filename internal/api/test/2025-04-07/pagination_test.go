@@ -0,0 +1,132 @@
+package v20250407
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	externalRef0 "github.com/snyk/code-client-go/internal/api/test/2025-04-07/common"
+	v20250407 "github.com/snyk/code-client-go/internal/api/test/2025-04-07/models"
+)
+
+func newComponentsPage(t *testing.T, ids []string, next string) []byte {
+	t.Helper()
+	page := NewGetComponentsState()
+	page.Data = nil
+	for _, id := range ids {
+		page.Data = append(page.Data, v20250407.GetComponentsResponseItem{
+			Attributes: v20250407.ComponentAttributes{Id: id, Type: "sast", Success: true},
+			Id:         id,
+			Type:       v20250407.Component,
+		})
+	}
+	if next != "" {
+		page.Links.Next = &externalRef0.LinkProperty{}
+		require.NoError(t, page.Links.Next.FromLinkProperty0(next))
+	}
+	body, err := json.Marshal(page)
+	require.NoError(t, err)
+	return body
+}
+
+func TestGetAllComponents_FollowsNextLinks(t *testing.T) {
+	orgId := uuid.New()
+	testId := uuid.New()
+	componentsPath := fmt.Sprintf("/hidden/orgs/%s/tests/%s/components", orgId, testId)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, componentsPath, r.URL.Path)
+		assert.Equal(t, "editor", r.Header.Get("X-Test"))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Query().Get("starting_after") {
+		case "":
+			// links relative to the server
+			_, _ = w.Write(newComponentsPage(t, []string{"1", "2"}, fmt.Sprintf("/orgs/%s/tests/%s/components?version=%s&starting_after=2", orgId, testId, ApiVersion)))
+		case "2":
+			// links that already contain the path of the server
+			_, _ = w.Write(newComponentsPage(t, []string{"3"}, componentsPath+"?version="+ApiVersion+"&starting_after=3"))
+		default:
+			_, _ = w.Write(newComponentsPage(t, []string{"4"}, ""))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/hidden", WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("X-Test", "editor")
+		return nil
+	}))
+	require.NoError(t, err)
+
+	components, err := client.GetAllComponents(t.Context(), orgId, testId, &GetComponentsParams{Version: ApiVersion})
+
+	require.NoError(t, err)
+	ids := []string{}
+	for _, component := range components {
+		ids = append(ids, component.Id)
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+}
+
+func TestGetAllComponents_DetectsLoops(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write(newComponentsPage(t, []string{"1"}, "/same-page"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = client.GetAllComponents(t.Context(), uuid.New(), uuid.New(), &GetComponentsParams{Version: ApiVersion})
+
+	assert.ErrorContains(t, err, "pagination loop detected")
+}
+
+func TestGetAllComponents_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = client.GetAllComponents(t.Context(), uuid.New(), uuid.New(), &GetComponentsParams{Version: ApiVersion})
+
+	assert.ErrorContains(t, err, "unexpected response status \"502\"")
+}
+
+func TestLinkHref(t *testing.T) {
+	t.Run("no link", func(t *testing.T) {
+		href, err := LinkHref(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, href)
+	})
+
+	t.Run("string link", func(t *testing.T) {
+		link := &externalRef0.LinkProperty{}
+		require.NoError(t, link.FromLinkProperty0("/next"))
+
+		href, err := LinkHref(link)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/next", href)
+	})
+
+	t.Run("object link", func(t *testing.T) {
+		link := &externalRef0.LinkProperty{}
+		require.NoError(t, link.FromLinkProperty1(externalRef0.LinkProperty1{Href: "/next"}))
+
+		href, err := LinkHref(link)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/next", href)
+	})
+}
@@ -31,16 +31,19 @@ import (
 )
 
 const (
-	ConfigurationTestFLowName    = "internal_code_test_flow_name"
-	ConfigurationReportFlag      = "report"
-	ConfigurationProjectName     = "project-name"
-	ConfigurationProjectTags     = "project-tags"
-	ConfigurationTargetName      = "target-name"
-	ConfigurationTargetReference = "target-reference"
-	ConfigurationProjectId       = "project-id"
-	ConfigurationCommitId        = "commit-id"
-	ConfigurationSastSettings    = "internal_sast_settings"
-	ConfigurationSlceEnabled     = "internal_snyk_scle_enabled"
+	ConfigurationTestFLowName      = "internal_code_test_flow_name"
+	ConfigurationReportFlag        = "report"
+	ConfigurationProjectName       = "project-name"
+	ConfigurationProjectTags       = "project-tags"
+	ConfigurationTargetName        = "target-name"
+	ConfigurationTargetReference   = "target-reference"
+	ConfigurationProjectId         = "project-id"
+	ConfigurationCommitId          = "commit-id"
+	ConfigurationExclusionGlobs    = "exclusion-globs"
+	ConfigurationScanners          = "scanners"
+	ConfigurationSastSettings      = "internal_sast_settings"
+	ConfigurationSlceEnabled       = "internal_snyk_scle_enabled"
+	ConfigurationSeverityThreshold = "severity-threshold"
 
 	MetadataBundleHash = "Snyk-Bundle-Hash"
 )
@@ -132,6 +135,11 @@ func EntryPointNative(invocationCtx workflow.InvocationContext, opts ...Optional
 
 	logger.Debug().Msgf("Result metadata: %+v", resultMetaData)
 
+	result, resultMetaData, filterErr := filterBySeverityThreshold(config, result, resultMetaData)
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
 	resultAvailable := true
 	if result == nil {
 		resultAvailable = false
@@ -230,10 +238,39 @@ func defaultAnalyzeFunction(ctx context.Context, path string, httpClientFunc fun
 
 	analysisOptions := []codeclient.AnalysisOption{}
 
+	// The legacy analyses of Snyk Code Local Engine can neither exclude files nor select scanners, so these flags
+	// are rejected rather than silently ignored.
+	if exclusionGlobs := GenerateExclusionGlobs(config); exclusionGlobs != nil {
+		if config.GetBool(ConfigurationSlceEnabled) {
+			return nil, "", nil, errors.New("--" + ConfigurationExclusionGlobs + " is not supported with Snyk Code Local Engine")
+		}
+		analysisOptions = append(analysisOptions, codeclient.WithExclusionGlobs(*exclusionGlobs))
+	}
+
+	scanners, err := GenerateScanners(config)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if scanners != nil {
+		if config.GetBool(ConfigurationSlceEnabled) {
+			return nil, "", nil, errors.New("--" + ConfigurationScanners + " is not supported with Snyk Code Local Engine")
+		}
+		analysisOptions = append(analysisOptions, codeclient.WithScanners(scanners...))
+	}
+
 	codeScannerOptions := []codeclient.OptionFunc{
 		codeclient.WithLogger(logger),
 		codeclient.WithTrackerFactory(progressFactory),
 		codeclient.WithFlow(config.GetString(ConfigurationTestFLowName)),
+		codeclient.WithLegacyFlow(config.GetString(ConfigurationTestFLowName)),
+		// the display name of the organization is not known here, so the default of the legacy request context applies
+		codeclient.WithLegacyOrg(config.GetString(configuration.ORGANIZATION_SLUG), "", nil),
+	}
+
+	// Snyk Code Local Engine implements the old deeproxy API rather than the new
+	// test service, so SCLE scans must use the deeproxy engine.
+	if config.GetBool(ConfigurationSlceEnabled) {
+		codeScannerOptions = append(codeScannerOptions, codeclient.WithEngine(codeclient.DeeproxyEngine))
 	}
 
 	codeScanner := codeclient.NewCodeScanner(
@@ -252,8 +289,8 @@ func defaultAnalyzeFunction(ctx context.Context, path string, httpClientFunc fun
 			return nil, "", nil, errors.Join(errors.New("\"project-id\" must be a valid UUID"), parseErr)
 		}
 
-		option := codeclient.ReportRemoteTest(projectId, config.GetString(ConfigurationCommitId))
-		result, resultMetaData, err = codeScanner.AnalyzeRemote(ctx, option)
+		analysisOptions = append(analysisOptions, codeclient.ReportRemoteTest(projectId, config.GetString(ConfigurationCommitId)))
+		result, resultMetaData, err = codeScanner.AnalyzeRemote(ctx, analysisOptions...)
 		// return report mode as bundleHash value to avoid potential bundleHash error checks
 		return result, string(reportMode), resultMetaData, err
 	}
@@ -285,48 +322,105 @@ func defaultAnalyzeFunction(ctx context.Context, path string, httpClientFunc fun
 	logger.Debug().Msgf("Target: %s", target)
 
 	changedFiles := make(map[string]bool)
-	var bundleHash string
 
-	// Snyk Code Local Engine implements the old deeproxy API rather than the new
-	// test service, so SCLE scans must use the legacy ("deeproxy") scanner.
+	engineOptions := []codeclient.EngineOption{codeclient.WithAnalysisOptions(analysisOptions...)}
 	if config.GetBool(ConfigurationSlceEnabled) {
-		return analyzeWithLegacyEngine(ctx, codeScanner, requestId, target, files, changedFiles, logger)
+		legacyOptions, legacyErr := legacyAnalysisOptions(config)
+		if legacyErr != nil {
+			return nil, "", nil, legacyErr
+		}
+		engineOptions = append(engineOptions, codeclient.WithLegacyAnalysisOptions(legacyOptions...))
+		// the scan source attributes the local engine analysis to the CLI rather than to an IDE
+		ctx = scan.NewContextWithScanSource(ctx, scan.CLI)
 	}
 
-	result, bundleHash, resultMetaData, err = codeScanner.UploadAndAnalyzeWithOptions(ctx, requestId, target, files, changedFiles, analysisOptions...)
+	return analyzeWithEngine(ctx, codeScanner.Engine(), requestId, target, files, changedFiles, logger, engineOptions...)
+}
 
-	return result, bundleHash, resultMetaData, err
+// filterBySeverityThreshold removes the findings below the severity threshold,
+// so that neither the summary nor the findings report them. The severity
+// counts of the result metadata are filtered alike.
+func filterBySeverityThreshold(config configuration.Configuration, result *sarif.SarifResponse, resultMetaData *scan.ResultMetaData) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	threshold := config.GetString(ConfigurationSeverityThreshold)
+	if threshold == "" {
+		return result, resultMetaData, nil
+	}
+	severity, err := sarif.ParseSeverity(threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if result != nil {
+		filtered := sarif.NewFilter().WithMinimumSeverity(severity).ApplyResponse(*result)
+		result = &filtered
+	}
+	if resultMetaData != nil {
+		filtered := *resultMetaData
+		filtered.SeverityCounts = severityCountsAtLeast(filtered.SeverityCounts, severity)
+		filtered.Components = make([]scan.TestComponent, 0, len(resultMetaData.Components))
+		for _, component := range resultMetaData.Components {
+			component.SeverityCounts = severityCountsAtLeast(component.SeverityCounts, severity)
+			filtered.Components = append(filtered.Components, component)
+		}
+		resultMetaData = &filtered
+	}
+	return result, resultMetaData, nil
+}
+
+// severityCountsAtLeast drops the counts of the severities below the threshold.
+func severityCountsAtLeast(counts scan.SeverityCounts, threshold sarif.Severity) scan.SeverityCounts {
+	switch threshold {
+	case sarif.SeverityCritical:
+		return scan.SeverityCounts{Critical: counts.Critical}
+	case sarif.SeverityHigh:
+		return scan.SeverityCounts{Critical: counts.Critical, High: counts.High}
+	case sarif.SeverityMedium:
+		return scan.SeverityCounts{Critical: counts.Critical, High: counts.High, Medium: counts.Medium}
+	default:
+		return counts
+	}
+}
+
+// legacyAnalysisOptions maps the severity threshold onto a server-side filter of the legacy scanner. A critical
+// threshold is filtered client-side by filterBySeverityThreshold.
+func legacyAnalysisOptions(config configuration.Configuration) ([]codeclient.LegacyAnalysisOption, error) {
+	threshold := config.GetString(ConfigurationSeverityThreshold)
+	if threshold == "" {
+		return nil, nil
+	}
+	severity, err := codeclient.ParseLegacySeverity(threshold)
+	if err != nil {
+		return nil, err
+	}
+	return []codeclient.LegacyAnalysisOption{codeclient.WithLegacySeverity(severity)}, nil
 }
 
-// analyzeWithLegacyEngine runs the deeproxy-based ("legacy") scanner, which is
-// the API surface that Snyk Code Local Engine implements. The legacy scanner
-// streams progress over a status channel, so it is drained concurrently to keep
-// the analysis goroutine from blocking on send.
+// analyzeWithEngine runs the analysis engine that was selected for the scan.
+// The progress is only logged, as the tracker factory already reports it to
+// the user interface.
 //
 // Its return signature mirrors the OptionalAnalysisFunctions contract; the
-// ResultMetaData is always nil because the legacy/deeproxy API returns no
-// test-service metadata (web UI URL, project/snapshot id) — there is none to
-// surface for an SCLE scan.
-func analyzeWithLegacyEngine(
+// ResultMetaData is nil for the deeproxy engine, because the legacy/deeproxy
+// API returns no test-service metadata (web UI URL, project/snapshot id).
+func analyzeWithEngine(
 	ctx context.Context,
-	codeScanner codeclient.CodeScanner,
+	engine codeclient.AnalysisEngine,
 	requestId string,
 	target scan.Target,
 	files <-chan string,
 	changedFiles map[string]bool,
 	logger *zerolog.Logger,
+	options ...codeclient.EngineOption,
 ) (*sarif.SarifResponse, string, *scan.ResultMetaData, error) {
-	statusChannel := make(chan scan.LegacyScanStatus)
+	progress := make(chan scan.LegacyScanStatus)
 	go func() {
-		for status := range statusChannel {
-			logger.Trace().Msgf("Snyk Code Local Engine analysis status: %s", status.Message)
+		for status := range progress {
+			logger.Trace().Msgf("Snyk Code analysis status: %s", status.Message)
 		}
 	}()
 
-	// shardKey is used by deeproxy only for cache sharding and is optional here.
-	const shardKey = ""
-	result, bundleHash, err := codeScanner.UploadAndAnalyzeLegacy(ctx, requestId, target, shardKey, files, changedFiles, statusChannel)
-	return result, bundleHash, nil, err
+	options = append(options, codeclient.WithProgress(progress))
+	return engine.Analyze(ctx, requestId, target, files, changedFiles, options...)
 }
 
 func determineAnalyzeInput(path string, config configuration.Configuration, logger *zerolog.Logger) (scan.Target, <-chan string, error) {
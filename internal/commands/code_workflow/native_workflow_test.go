@@ -1,7 +1,10 @@
 package code_workflow
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -14,7 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/snyk/code-client-go/bundle"
+	codeclient "github.com/snyk/code-client-go"
 	"github.com/snyk/code-client-go/pkg/code/sast_contract"
 	"github.com/snyk/code-client-go/sarif"
 	"github.com/snyk/code-client-go/scan"
@@ -39,6 +42,41 @@ func Test_defaultAnalyzeFunction_reportNotSupportedWithSCLE(t *testing.T) {
 	})
 }
 
+func Test_defaultAnalyzeFunction_analysisOptionsNotSupportedWithSCLE(t *testing.T) {
+	logger := zerolog.Nop()
+
+	newConfig := func() configuration.Configuration {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSlceEnabled, true)
+		config.Set(ConfigurationSastSettings, &sast_contract.SastResponse{
+			SastEnabled: true,
+			LocalCodeEngine: sast_contract.LocalCodeEngine{
+				Enabled: true,
+				Url:     "http://localhost:1234",
+			},
+		})
+		return config
+	}
+
+	t.Run("errors when --exclusion-globs is set for an SCLE org", func(t *testing.T) {
+		config := newConfig()
+		config.Set(ConfigurationExclusionGlobs, "vendor/**")
+
+		_, _, _, err := defaultAnalyzeFunction(context.Background(), t.TempDir(), nil, &logger, config, nil)
+
+		assert.ErrorContains(t, err, "--exclusion-globs is not supported with Snyk Code Local Engine")
+	})
+
+	t.Run("errors when --scanners is set for an SCLE org", func(t *testing.T) {
+		config := newConfig()
+		config.Set(ConfigurationScanners, "sast")
+
+		_, _, _, err := defaultAnalyzeFunction(context.Background(), t.TempDir(), nil, &logger, config, nil)
+
+		assert.ErrorContains(t, err, "--scanners is not supported with Snyk Code Local Engine")
+	})
+}
+
 func Test_defaultAnalyzeFunction_usesLocalEngineLegacyEndpoints(t *testing.T) {
 	logger := zerolog.Nop()
 	const bundleHash = "legacy-bundle-hash"
@@ -123,12 +161,81 @@ func Test_defaultAnalyzeFunction_usesLocalEngineLegacyEndpoints(t *testing.T) {
 	}, requests)
 }
 
-type fakeLegacyCodeScanner struct {
-	called        bool
-	shardKey      string
-	statusMessage string
-	response      *sarif.SarifResponse
-	bundleHash    string
+func Test_defaultAnalyzeFunction_criticalSeverityThresholdWithSCLE(t *testing.T) {
+	logger := zerolog.Nop()
+	const bundleHash = "legacy-bundle-hash"
+
+	var analysisRequest map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/filters":
+			_, _ = w.Write([]byte(`{"configFiles":[],"extensions":[".js"]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/bundle":
+			_, _ = w.Write([]byte(`{"bundleHash":"` + bundleHash + `","missingFiles":["app.js"]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/bundle/"+bundleHash:
+			_, _ = w.Write([]byte(`{"bundleHash":"` + bundleHash + `","missingFiles":[]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/analysis":
+			// the request body is base64 encoded and gzipped
+			zipReader, err := gzip.NewReader(r.Body)
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.NoError(t, json.NewDecoder(base64.NewDecoder(base64.StdEncoding, zipReader)).Decode(&analysisRequest))
+			_, _ = w.Write([]byte(`{
+				"type":"sarif",
+				"progress":1.0,
+				"status":"COMPLETE",
+				"timing":{"fetchingCode":1,"queue":1,"analysis":1},
+				"coverage":[],
+				"sarif":{"version":"2.1.0","runs":[{"results":[{"ruleId":"high","level":"error"}]}]}
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := t.TempDir()
+	writeFile(t, filepath.Join(path, "app.js"))
+
+	config := configuration.NewWithOpts()
+	config.Set(configuration.API_URL, "https://api.snyk.io")
+	config.Set(configuration.ORGANIZATION, "test-org")
+	config.Set(configuration.MAX_THREADS, 1)
+	config.Set(configuration.FLAG_REMOTE_REPO_URL, "https://github.com/snyk/nodejs-goof")
+	config.Set(ConfigurationSeverityThreshold, "critical")
+	config.Set(ConfigurationSlceEnabled, true)
+	config.Set(ConfigurationSastSettings, &sast_contract.SastResponse{
+		SastEnabled: true,
+		LocalCodeEngine: sast_contract.LocalCodeEngine{
+			Enabled: true,
+			Url:     server.URL,
+		},
+	})
+
+	result, _, resultMetaData, err := defaultAnalyzeFunction(
+		context.Background(),
+		path,
+		func() *http.Client { return server.Client() },
+		&logger,
+		config,
+		ui.DefaultUi(),
+	)
+	require.NoError(t, err)
+	// the legacy scanner knows no critical severity, it returns the high findings
+	assert.EqualValues(t, codeclient.LegacySeverityHigh, analysisRequest["severity"])
+
+	filtered, _, err := filterBySeverityThreshold(config, result, resultMetaData)
+	require.NoError(t, err)
+	assert.Empty(t, filtered.Sarif.Runs[0].Results)
+}
+
+type fakeAnalysisEngine struct {
+	called     bool
+	options    []codeclient.EngineOption
+	response   *sarif.SarifResponse
+	bundleHash string
 }
 
 type fakeTarget struct {
@@ -139,60 +246,142 @@ func (f fakeTarget) GetPath() string {
 	return f.path
 }
 
-func (f *fakeLegacyCodeScanner) Upload(context.Context, string, scan.Target, <-chan string, map[string]bool) (bundle.Bundle, error) {
-	panic("Upload should not be called by analyzeWithLegacyEngine")
-}
-
-func (f *fakeLegacyCodeScanner) UploadAndAnalyze(context.Context, string, scan.Target, <-chan string, map[string]bool) (*sarif.SarifResponse, string, error) {
-	panic("UploadAndAnalyze should not be called by analyzeWithLegacyEngine")
-}
-
-func (f *fakeLegacyCodeScanner) UploadAndAnalyzeLegacy(
+func (f *fakeAnalysisEngine) Analyze(
 	_ context.Context,
 	_ string,
 	_ scan.Target,
-	shardKey string,
 	_ <-chan string,
 	_ map[string]bool,
-	statusChannel chan<- scan.LegacyScanStatus,
-) (*sarif.SarifResponse, string, error) {
+	options ...codeclient.EngineOption,
+) (*sarif.SarifResponse, string, *scan.ResultMetaData, error) {
 	f.called = true
-	f.shardKey = shardKey
-	statusChannel <- scan.LegacyScanStatus{Message: f.statusMessage}
-	close(statusChannel)
-	return f.response, f.bundleHash, nil
+	f.options = options
+	return f.response, f.bundleHash, nil, nil
 }
 
-func Test_analyzeWithLegacyEngine(t *testing.T) {
+func Test_analyzeWithEngine(t *testing.T) {
 	logger := zerolog.Nop()
 	response := &sarif.SarifResponse{Status: "COMPLETE"}
-	scanner := &fakeLegacyCodeScanner{
-		response:      response,
-		bundleHash:    "legacy-bundle-hash",
-		statusMessage: "analysis complete",
+	engine := &fakeAnalysisEngine{
+		response:   response,
+		bundleHash: "legacy-bundle-hash",
 	}
 
 	files := make(chan string)
 	close(files)
 
-	actualResponse, actualBundleHash, actualMetaData, err := analyzeWithLegacyEngine(
+	actualResponse, actualBundleHash, actualMetaData, err := analyzeWithEngine(
 		context.Background(),
-		scanner,
+		engine,
 		"request-id",
 		fakeTarget{path: t.TempDir()},
 		files,
 		map[string]bool{},
 		&logger,
+		codeclient.WithLegacyAnalysisOptions(codeclient.WithLegacySeverity(codeclient.LegacySeverityHigh)),
 	)
 
 	assert.NoError(t, err)
-	assert.True(t, scanner.called)
-	assert.Empty(t, scanner.shardKey)
+	assert.True(t, engine.called)
+	// the given option and the progress
+	assert.Len(t, engine.options, 2)
 	assert.Same(t, response, actualResponse)
 	assert.Equal(t, "legacy-bundle-hash", actualBundleHash)
 	assert.Nil(t, actualMetaData)
 }
 
+func Test_filterBySeverityThreshold(t *testing.T) {
+	result := &sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{{
+		Results: []sarif.Result{
+			{RuleID: "high", Level: "error"},
+			{RuleID: "medium", Level: "warning"},
+			{RuleID: "low", Level: "note"},
+		},
+	}}}}
+	counts := scan.SeverityCounts{Critical: 1, High: 2, Medium: 3, Low: 4}
+	resultMetaData := &scan.ResultMetaData{
+		SeverityCounts: counts,
+		Components:     []scan.TestComponent{{Type: "sast", SeverityCounts: counts}},
+	}
+
+	t.Run("without severity threshold", func(t *testing.T) {
+		filtered, filteredMetaData, err := filterBySeverityThreshold(configuration.NewWithOpts(), result, resultMetaData)
+		require.NoError(t, err)
+		assert.Same(t, result, filtered)
+		assert.Same(t, resultMetaData, filteredMetaData)
+	})
+
+	t.Run("with severity threshold", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSeverityThreshold, "medium")
+
+		filtered, filteredMetaData, err := filterBySeverityThreshold(config, result, resultMetaData)
+		require.NoError(t, err)
+		require.Len(t, filtered.Sarif.Runs[0].Results, 2)
+		assert.Equal(t, "high", filtered.Sarif.Runs[0].Results[0].RuleID)
+		assert.Equal(t, "medium", filtered.Sarif.Runs[0].Results[1].RuleID)
+
+		expectedCounts := scan.SeverityCounts{Critical: 1, High: 2, Medium: 3}
+		assert.Equal(t, expectedCounts, filteredMetaData.SeverityCounts)
+		assert.Equal(t, expectedCounts, filteredMetaData.Components[0].SeverityCounts)
+		// the original metadata is left untouched
+		assert.Equal(t, counts, resultMetaData.SeverityCounts)
+		assert.Equal(t, counts, resultMetaData.Components[0].SeverityCounts)
+	})
+
+	t.Run("with invalid severity threshold", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSeverityThreshold, "urgent")
+
+		_, _, err := filterBySeverityThreshold(config, result, resultMetaData)
+		assert.Error(t, err)
+	})
+
+	t.Run("without result", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSeverityThreshold, "high")
+
+		filtered, filteredMetaData, err := filterBySeverityThreshold(config, nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, filtered)
+		assert.Nil(t, filteredMetaData)
+	})
+}
+
+func Test_legacyAnalysisOptions(t *testing.T) {
+	t.Run("without severity threshold", func(t *testing.T) {
+		options, err := legacyAnalysisOptions(configuration.NewWithOpts())
+		require.NoError(t, err)
+		assert.Empty(t, options)
+	})
+
+	t.Run("with severity threshold", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSeverityThreshold, "high")
+
+		options, err := legacyAnalysisOptions(config)
+		require.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+
+	t.Run("with critical severity threshold", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSeverityThreshold, "critical")
+
+		options, err := legacyAnalysisOptions(config)
+		require.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+
+	t.Run("with invalid severity threshold", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationSeverityThreshold, "urgent")
+
+		_, err := legacyAnalysisOptions(config)
+		assert.ErrorContains(t, err, "invalid severity")
+	})
+}
+
 func writeFile(t *testing.T, filename string) {
 	t.Helper()
 	err := os.WriteFile(filename, []byte("hello"), 0644)
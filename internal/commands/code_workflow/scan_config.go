@@ -0,0 +1,50 @@
+package code_workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/snyk/go-application-framework/pkg/configuration"
+)
+
+var supportedScanners = []string{"sast", "sca", "secrets", "legacy_scanners"}
+
+func GenerateExclusionGlobs(config configuration.Configuration) *[]string {
+	if !config.IsSet(ConfigurationExclusionGlobs) {
+		return nil
+	}
+
+	globs := splitCommaSeparated(config.GetString(ConfigurationExclusionGlobs))
+	if globs == nil {
+		return nil
+	}
+	return &globs
+}
+
+func GenerateScanners(config configuration.Configuration) ([]string, error) {
+	if !config.IsSet(ConfigurationScanners) {
+		return nil, nil
+	}
+
+	scanners := splitCommaSeparated(config.GetString(ConfigurationScanners))
+	for _, scanner := range scanners {
+		if !slices.Contains(supportedScanners, scanner) {
+			return nil, fmt.Errorf(`The scanner "%s" is not supported. Supported scanners are: %s`, scanner, strings.Join(supportedScanners, ", "))
+		}
+	}
+
+	return scanners, nil
+}
+
+// splitCommaSeparated returns the non-blank values of a comma-separated list, or nil if there are none.
+func splitCommaSeparated(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
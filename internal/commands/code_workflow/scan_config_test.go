@@ -0,0 +1,77 @@
+package code_workflow
+
+import (
+	"testing"
+
+	"github.com/snyk/go-application-framework/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateExclusionGlobs(t *testing.T) {
+	t.Run("returns nil when exclusion globs are not set", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+
+		assert.Nil(t, GenerateExclusionGlobs(config))
+	})
+
+	t.Run("returns nil when exclusion globs are empty", func(t *testing.T) {
+		for _, raw := range []string{"", " , "} {
+			config := configuration.NewWithOpts()
+			config.Set(ConfigurationExclusionGlobs, raw)
+
+			assert.Nil(t, GenerateExclusionGlobs(config))
+		}
+	})
+
+	t.Run("parses exclusion globs", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationExclusionGlobs, "vendor/**, **/*_test.go,")
+
+		globs := GenerateExclusionGlobs(config)
+
+		assert.NotNil(t, globs)
+		assert.Equal(t, []string{"vendor/**", "**/*_test.go"}, *globs)
+	})
+}
+
+func TestGenerateScanners(t *testing.T) {
+	t.Run("returns nil when scanners are not set", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+
+		scanners, err := GenerateScanners(config)
+
+		assert.NoError(t, err)
+		assert.Nil(t, scanners)
+	})
+
+	t.Run("returns nil when scanners are empty", func(t *testing.T) {
+		for _, raw := range []string{"", " , "} {
+			config := configuration.NewWithOpts()
+			config.Set(ConfigurationScanners, raw)
+
+			scanners, err := GenerateScanners(config)
+
+			assert.NoError(t, err)
+			assert.Nil(t, scanners)
+		}
+	})
+
+	t.Run("parses scanners", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationScanners, "sast,secrets")
+
+		scanners, err := GenerateScanners(config)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sast", "secrets"}, scanners)
+	})
+
+	t.Run("errors on unsupported scanner", func(t *testing.T) {
+		config := configuration.NewWithOpts()
+		config.Set(ConfigurationScanners, "sast,dast")
+
+		_, err := GenerateScanners(config)
+
+		assert.ErrorContains(t, err, `The scanner "dast" is not supported`)
+	})
+}
@@ -0,0 +1,219 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package uploadrevision implements a client for the upload revision API, which stores the files of a test on the
+// server as an alternative to deepcode bundles.
+package uploadrevision
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/rs/zerolog"
+
+	"github.com/snyk/code-client-go/config"
+	codeClientHTTP "github.com/snyk/code-client-go/http"
+	testApi "github.com/snyk/code-client-go/internal/api/test/2025-04-07"
+	"github.com/snyk/code-client-go/internal/util"
+	"github.com/snyk/code-client-go/observability"
+)
+
+//go:generate go tool github.com/golang/mock/mockgen -destination=mocks/client.go -source=client.go -package mocks
+
+const ApiVersion = "2024-10-15"
+
+const (
+	revisionType     = "upload_revision"
+	snapshotRevision = "snapshot"
+	jsonApiMediaType = "application/vnd.api+json"
+)
+
+// Client creates upload revisions, uploads files to them and seals them. A sealed revision can be tested with the
+// id returned by CreateRevision.
+type Client interface {
+	CreateRevision(ctx context.Context, orgId string) (revisionId string, err error)
+
+	// UploadFiles uploads the given contents by their relative path to an unsealed revision.
+	UploadFiles(ctx context.Context, orgId string, revisionId string, files map[string][]byte) error
+
+	// SealRevision marks the revision as complete. No files can be uploaded to a sealed revision.
+	SealRevision(ctx context.Context, orgId string, revisionId string) error
+}
+
+type revisionDocument struct {
+	Data revisionData `json:"data"`
+}
+
+type revisionData struct {
+	Id         string             `json:"id,omitempty"`
+	Type       string             `json:"type"`
+	Attributes revisionAttributes `json:"attributes"`
+}
+
+type revisionAttributes struct {
+	RevisionType string `json:"revision_type,omitempty"`
+	Sealed       bool   `json:"sealed,omitempty"`
+}
+
+type client struct {
+	httpClient   codeClientHTTP.HTTPClient
+	instrumentor observability.Instrumentor
+	logger       *zerolog.Logger
+	config       config.Config
+}
+
+func NewClient(
+	config config.Config,
+	httpClient codeClientHTTP.HTTPClient,
+	logger *zerolog.Logger,
+	instrumentor observability.Instrumentor,
+) *client {
+	return &client{
+		httpClient:   httpClient,
+		instrumentor: instrumentor,
+		logger:       logger,
+		config:       config,
+	}
+}
+
+func (c *client) CreateRevision(ctx context.Context, orgId string) (string, error) {
+	method := "uploadrevision.CreateRevision"
+	span := c.instrumentor.StartSpan(ctx, method)
+	defer c.instrumentor.Finish(span)
+
+	requestBody, err := json.Marshal(revisionDocument{Data: revisionData{
+		Type:       revisionType,
+		Attributes: revisionAttributes{RevisionType: snapshotRevision},
+	}})
+	if err != nil {
+		return "", err
+	}
+
+	responseBody, err := c.request(ctx, http.MethodPost, c.revisionsUrl(orgId, ""), jsonApiMediaType, bytes.NewReader(requestBody))
+	if err != nil {
+		return "", err
+	}
+
+	var response revisionDocument
+	err = json.Unmarshal(responseBody, &response)
+	if err != nil {
+		return "", err
+	}
+	if response.Data.Id == "" {
+		return "", fmt.Errorf("%s: response contains no revision id", method)
+	}
+	c.logger.Debug().Str("method", method).Str("revisionId", response.Data.Id).Msg("created upload revision")
+	return response.Data.Id, nil
+}
+
+func (c *client) UploadFiles(ctx context.Context, orgId string, revisionId string, files map[string][]byte) error {
+	method := "uploadrevision.UploadFiles"
+	span := c.instrumentor.StartSpan(ctx, method)
+	defer c.instrumentor.Finish(span)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	requestBody := &bytes.Buffer{}
+	writer := multipart.NewWriter(requestBody)
+	for _, path := range paths {
+		part, err := writer.CreateFormFile(path, path)
+		if err != nil {
+			return err
+		}
+		_, err = part.Write(files[path])
+		if err != nil {
+			return err
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.request(ctx, http.MethodPost, c.revisionsUrl(orgId, revisionId+"/files"), writer.FormDataContentType(), requestBody)
+	if err != nil {
+		return err
+	}
+	c.logger.Debug().Str("method", method).Str("revisionId", revisionId).Int("fileCount", len(files)).Msg("uploaded files")
+	return nil
+}
+
+func (c *client) SealRevision(ctx context.Context, orgId string, revisionId string) error {
+	method := "uploadrevision.SealRevision"
+	span := c.instrumentor.StartSpan(ctx, method)
+	defer c.instrumentor.Finish(span)
+
+	requestBody, err := json.Marshal(revisionDocument{Data: revisionData{
+		Id:         revisionId,
+		Type:       revisionType,
+		Attributes: revisionAttributes{Sealed: true},
+	}})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.request(ctx, http.MethodPatch, c.revisionsUrl(orgId, revisionId), jsonApiMediaType, bytes.NewReader(requestBody))
+	return err
+}
+
+// revisionsUrl returns the URL of the upload revisions of the organization, or of the given sub path of them.
+func (c *client) revisionsUrl(orgId string, path string) string {
+	revisionsUrl := fmt.Sprintf("%s/orgs/%s/upload_revisions", util.RestApiUrl(c.config.SnykApi(), true), url.PathEscape(orgId))
+	if path != "" {
+		revisionsUrl += "/" + path
+	}
+	return revisionsUrl + "?version=" + ApiVersion
+}
+
+func (c *client) request(ctx context.Context, method string, requestUrl string, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	codeClientHTTP.AddDefaultHeaders(req, codeClientHTTP.NoRequestId, c.config.Organization(), method, false)
+	req.Header.Set("Content-Type", contentType)
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		closeErr := response.Body.Close()
+		if closeErr != nil {
+			c.logger.Error().Err(closeErr).Msg("failed to close response body")
+		}
+	}()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, testApi.NewErrorFromResponse(response.StatusCode, responseBody)
+	}
+	return responseBody, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package uploadrevision_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	confMocks "github.com/snyk/code-client-go/config/mocks"
+	"github.com/snyk/code-client-go/internal/uploadrevision"
+	"github.com/snyk/code-client-go/observability"
+)
+
+const orgId = "4a72d1db-b465-4764-99e1-ecedad03b06a"
+
+func newClient(t *testing.T, handler http.HandlerFunc) uploadrevision.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().SnykApi().Return(server.URL).AnyTimes()
+	mockConfig.EXPECT().Organization().Return(orgId).AnyTimes()
+	logger := zerolog.Nop()
+	return uploadrevision.NewClient(mockConfig, server.Client(), &logger, observability.NewInstrumentor())
+}
+
+func TestClient_CreateRevision(t *testing.T) {
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/hidden/orgs/"+orgId+"/upload_revisions", r.URL.Path)
+		assert.Equal(t, uploadrevision.ApiVersion, r.URL.Query().Get("version"))
+		assert.Equal(t, "application/vnd.api+json", r.Header.Get("Content-Type"))
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		data := body["data"].(map[string]any)
+		assert.Equal(t, "upload_revision", data["type"])
+		assert.Equal(t, "snapshot", data["attributes"].(map[string]any)["revision_type"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data": {"id": "revision-id", "type": "upload_revision", "attributes": {"revision_type": "snapshot", "sealed": false}}}`))
+	})
+
+	revisionId, err := client.CreateRevision(t.Context(), orgId)
+
+	require.NoError(t, err)
+	assert.Equal(t, "revision-id", revisionId)
+}
+
+func TestClient_UploadFiles(t *testing.T) {
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/hidden/orgs/"+orgId+"/upload_revisions/revision-id/files", r.URL.Path)
+
+		reader, err := r.MultipartReader()
+		require.NoError(t, err)
+		files := map[string]string{}
+		for {
+			part, partErr := reader.NextPart()
+			if partErr == io.EOF {
+				break
+			}
+			require.NoError(t, partErr)
+			content, readErr := io.ReadAll(part)
+			require.NoError(t, readErr)
+			files[part.FormName()] = string(content)
+		}
+		assert.Equal(t, map[string]string{"src/Main.java": "class Main {}", "pom.xml": "<project/>"}, files)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.UploadFiles(t.Context(), orgId, "revision-id", map[string][]byte{
+		"src/Main.java": []byte("class Main {}"),
+		"pom.xml":       []byte("<project/>"),
+	})
+
+	require.NoError(t, err)
+}
+
+func TestClient_SealRevision(t *testing.T) {
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/hidden/orgs/"+orgId+"/upload_revisions/revision-id", r.URL.Path)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		data := body["data"].(map[string]any)
+		assert.Equal(t, "revision-id", data["id"])
+		assert.Equal(t, true, data["attributes"].(map[string]any)["sealed"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"id": "revision-id", "type": "upload_revision", "attributes": {"sealed": true}}}`))
+	})
+
+	err := client.SealRevision(t.Context(), orgId, "revision-id")
+
+	require.NoError(t, err)
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"jsonapi": {"version": "1.0"}, "errors": [{"status": "403", "title": "Forbidden", "detail": "upload revisions are not enabled"}]}`))
+	})
+
+	_, err := client.CreateRevision(t.Context(), orgId)
+
+	assert.ErrorContains(t, err, "Forbidden")
+}
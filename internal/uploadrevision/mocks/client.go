@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// CreateRevision mocks base method.
+func (m *MockClient) CreateRevision(ctx context.Context, orgId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevision", ctx, orgId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRevision indicates an expected call of CreateRevision.
+func (mr *MockClientMockRecorder) CreateRevision(ctx, orgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevision", reflect.TypeOf((*MockClient)(nil).CreateRevision), ctx, orgId)
+}
+
+// SealRevision mocks base method.
+func (m *MockClient) SealRevision(ctx context.Context, orgId, revisionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SealRevision", ctx, orgId, revisionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SealRevision indicates an expected call of SealRevision.
+func (mr *MockClientMockRecorder) SealRevision(ctx, orgId, revisionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealRevision", reflect.TypeOf((*MockClient)(nil).SealRevision), ctx, orgId, revisionId)
+}
+
+// UploadFiles mocks base method.
+func (m *MockClient) UploadFiles(ctx context.Context, orgId, revisionId string, files map[string][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFiles", ctx, orgId, revisionId, files)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadFiles indicates an expected call of UploadFiles.
+func (mr *MockClientMockRecorder) UploadFiles(ctx, orgId, revisionId, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFiles", reflect.TypeOf((*MockClient)(nil).UploadFiles), ctx, orgId, revisionId, files)
+}
@@ -0,0 +1,35 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RestApiUrl returns the base URL of the public or, if isHidden is set, the hidden REST API for the configured
+// Snyk API URL.
+func RestApiUrl(snykApi string, isHidden bool) string {
+	apiUrl := strings.TrimRight(snykApi, "/")
+	// Temporary Workaround because intellij currently adds a /v1 suffix to the EndpointAPI
+	apiUrl = strings.Replace(apiUrl, "/v1", "", 1)
+	path := "rest"
+	if isHidden {
+		path = "hidden"
+	}
+	return fmt.Sprintf("%s/%s", apiUrl, path)
+}
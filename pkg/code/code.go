@@ -47,7 +47,7 @@ func GetCodeFlagSet() *pflag.FlagSet {
 	flagSet.String(code_workflow.ConfigurationProjectName, "", "The name of the project to test.")
 	flagSet.String(code_workflow.ConfigurationProjectTags, "", "Project tags to attach when publishing Code results.")
 	flagSet.String(configuration.FLAG_REMOTE_REPO_URL, "", "The URL of the remote repository to test.")
-	flagSet.String("severity-threshold", "", "Minimum severity level to report (low|medium|high)")
+	flagSet.String(code_workflow.ConfigurationSeverityThreshold, "", "Minimum severity level to report (low|medium|high|critical)")
 	flagSet.String("sarif-file-output", "", "Save test output in SARIF format directly to the <OUTPUT_FILE_PATH> file, regardless of whether or not you use the --sarif option.")
 	flagSet.String("json-file-output", "", "Save test output in JSON format directly to the <OUTPUT_FILE_PATH> file, regardless of whether or not you use the --json option.")
 	flagSet.String("project-id", "", "The unique identifier of the project to test.")
@@ -55,6 +55,8 @@ func GetCodeFlagSet() *pflag.FlagSet {
 	flagSet.String(code_workflow.ConfigurationTargetName, "", "The name of the target to test.")
 	flagSet.String(code_workflow.ConfigurationTargetReference, "", "The reference that differentiates this project, e.g. a branch name or version.")
 	flagSet.String("target-file", "", "The path to the target file to test.")
+	flagSet.String(code_workflow.ConfigurationExclusionGlobs, "", "Comma-separated glob patterns of files and directories to exclude from the test.")
+	flagSet.String(code_workflow.ConfigurationScanners, "", "Comma-separated list of scanners to run (sast|sca|secrets|legacy_scanners).")
 
 	return flagSet
 }
@@ -0,0 +1,122 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif
+
+import "strings"
+
+// Severity is the Snyk severity of a result.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+)
+
+// SeverityFromLevel maps a SARIF level onto a Snyk severity. Levels without a severity, e.g. "none", map to an empty
+// Severity.
+func SeverityFromLevel(level string) Severity {
+	switch level {
+	case "error":
+		return SeverityHigh
+	case "warning":
+		return SeverityMedium
+	case "note":
+		return SeverityLow
+	default:
+		return ""
+	}
+}
+
+// Results returns the results of all runs.
+func (d SarifDocument) Results() []Result {
+	var results []Result
+	for _, run := range d.Runs {
+		results = append(results, run.Results...)
+	}
+	return results
+}
+
+// RuleByID returns the rule with the given id.
+func (r Run) RuleByID(id string) (Rule, bool) {
+	for _, rule := range r.Tool.Driver.Rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Rule returns the rule of the result. It is looked up via the rule index and falls back to the rule id if the index
+// does not reference the rule of the result.
+func (r Run) Rule(result Result) (Rule, bool) {
+	rules := r.Tool.Driver.Rules
+	if result.RuleIndex >= 0 && result.RuleIndex < len(rules) && rules[result.RuleIndex].ID == result.RuleID {
+		return rules[result.RuleIndex], true
+	}
+	return r.RuleByID(result.RuleID)
+}
+
+// CWEs returns the CWEs of the rule of the result, e.g. "CWE-547".
+func (r Run) CWEs(result Result) []string {
+	rule, ok := r.Rule(result)
+	if !ok {
+		return nil
+	}
+	return rule.Properties.Cwe
+}
+
+// Severity returns the effective severity of the result. A result without a level inherits the default level of its
+// rule.
+func (r Run) Severity(result Result) Severity {
+	if severity := result.Severity(); severity != "" {
+		return severity
+	}
+	rule, ok := r.Rule(result)
+	if !ok {
+		return ""
+	}
+	return SeverityFromLevel(rule.DefaultConfiguration.Level)
+}
+
+// PrimaryLocation returns the first location of the result, which is where the finding is reported.
+func (r Result) PrimaryLocation() (PhysicalLocation, bool) {
+	if len(r.Locations) == 0 {
+		return PhysicalLocation{}, false
+	}
+	return r.Locations[0].PhysicalLocation, true
+}
+
+// IsSuppressed reports whether a suppression is in effect for the result. Suppressions that are still under review or
+// have been rejected are not in effect, suppressions without a status are treated as accepted.
+func (r Result) IsSuppressed() bool {
+	for _, suppression := range r.Suppressions {
+		if suppression.Status == Accepted || suppression.Status == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Severity returns the severity of the result. The severity from the snykPolicy/v1 properties takes precedence over
+// the level, as it reflects the policies of the organization. It is lowercased, e.g. "High" becomes SeverityHigh.
+func (r Result) Severity() Severity {
+	if r.Properties.Policy != nil && r.Properties.Policy.Severity != "" {
+		return Severity(strings.ToLower(r.Properties.Policy.Severity))
+	}
+	return SeverityFromLevel(r.Level)
+}
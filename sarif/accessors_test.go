@@ -0,0 +1,237 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/snyk/code-client-go/sarif"
+)
+
+func loadDocument(t *testing.T) sarif.SarifDocument {
+	t.Helper()
+	content, err := os.ReadFile("testdata/code.json")
+	require.NoError(t, err)
+	var document sarif.SarifDocument
+	require.NoError(t, json.Unmarshal(content, &document))
+	return document
+}
+
+func TestSeverityFromLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected sarif.Severity
+	}{
+		{level: "error", expected: sarif.SeverityHigh},
+		{level: "warning", expected: sarif.SeverityMedium},
+		{level: "note", expected: sarif.SeverityLow},
+		{level: "none", expected: ""},
+		{level: "", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sarif.SeverityFromLevel(tt.level))
+		})
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	document := loadDocument(t)
+	require.Len(t, document.Runs, 1)
+	run := document.Runs[0]
+	require.Len(t, run.Results, 6)
+
+	tests := []struct {
+		name       string
+		result     sarif.Result
+		ruleId     string
+		cwes       []string
+		uri        string
+		startLine  int
+		suppressed bool
+		severity   sarif.Severity
+	}{
+		{
+			name:      "error level",
+			result:    run.Results[0],
+			ruleId:    "javascript/HardcodedNonCryptoSecret",
+			cwes:      []string{"CWE-547"},
+			uri:       "scripts/db/migrations/20230811153738_add_generated_grouping_columns_to_collections_table.ts",
+			startLine: 4,
+			severity:  sarif.SeverityHigh,
+		},
+		{
+			name:      "warning level",
+			result:    run.Results[1],
+			ruleId:    "javascript/HttpToHttps",
+			cwes:      []string{"CWE-319"},
+			uri:       "scripts/db/cloudsqlproxy-quit.ts",
+			startLine: 8,
+			severity:  sarif.SeverityMedium,
+		},
+		{
+			name:       "accepted suppression",
+			result:     run.Results[2],
+			ruleId:     "javascript/HttpToHttps",
+			cwes:       []string{"CWE-319"},
+			uri:        "src/main.ts",
+			startLine:  58,
+			suppressed: true,
+			severity:   sarif.SeverityMedium,
+		},
+		{
+			name:      "suppression under review",
+			result:    run.Results[3],
+			ruleId:    "javascript/HttpToHttps",
+			cwes:      []string{"CWE-319"},
+			uri:       "src/main.ts",
+			startLine: 60,
+			severity:  sarif.SeverityMedium,
+		},
+		{
+			name:      "note level",
+			result:    run.Results[4],
+			ruleId:    "javascript/HardcodedNonCryptoSecret/test",
+			cwes:      []string{"CWE-547"},
+			uri:       "test/service-tests/service-utils/knex.service-spec.ts",
+			startLine: 72,
+			severity:  sarif.SeverityLow,
+		},
+		{
+			name:      "severity from snyk policy",
+			result:    run.Results[5],
+			ruleId:    "javascript/HardcodedNonCryptoSecret/test",
+			cwes:      []string{"CWE-547"},
+			uri:       "test/service-tests/service-utils/knex.service-spec.ts",
+			startLine: 76,
+			severity:  sarif.SeverityHigh,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := run.Rule(tt.result)
+			require.True(t, ok)
+			assert.Equal(t, tt.ruleId, rule.ID)
+			assert.Equal(t, tt.cwes, run.CWEs(tt.result))
+
+			location, ok := tt.result.PrimaryLocation()
+			require.True(t, ok)
+			assert.Equal(t, tt.uri, location.ArtifactLocation.URI)
+			assert.Equal(t, tt.startLine, location.Region.StartLine)
+
+			assert.Equal(t, tt.suppressed, tt.result.IsSuppressed())
+			assert.Equal(t, tt.severity, tt.result.Severity())
+			assert.Equal(t, tt.severity, run.Severity(tt.result))
+		})
+	}
+}
+
+func TestRun_Rule(t *testing.T) {
+	run := loadDocument(t).Runs[0]
+
+	tests := []struct {
+		name     string
+		result   sarif.Result
+		expected string
+		found    bool
+	}{
+		{name: "by rule index", result: sarif.Result{RuleID: "javascript/HttpToHttps", RuleIndex: 1}, expected: "javascript/HttpToHttps", found: true},
+		{name: "stale rule index", result: sarif.Result{RuleID: "javascript/HttpToHttps", RuleIndex: 0}, expected: "javascript/HttpToHttps", found: true},
+		{name: "rule index out of range", result: sarif.Result{RuleID: "javascript/HttpToHttps", RuleIndex: 42}, expected: "javascript/HttpToHttps", found: true},
+		{name: "unknown rule", result: sarif.Result{RuleID: "java/Unknown"}, found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := run.Rule(tt.result)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.expected, rule.ID)
+		})
+	}
+}
+
+func TestRun_Severity(t *testing.T) {
+	run := loadDocument(t).Runs[0]
+
+	tests := []struct {
+		name     string
+		result   sarif.Result
+		expected sarif.Severity
+	}{
+		{name: "level of the result", result: sarif.Result{RuleID: "javascript/HttpToHttps", RuleIndex: 1, Level: "error"}, expected: sarif.SeverityHigh},
+		{name: "default level of the rule", result: sarif.Result{RuleID: "javascript/HttpToHttps", RuleIndex: 1}, expected: sarif.SeverityMedium},
+		{name: "unknown rule", result: sarif.Result{RuleID: "java/Unknown"}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, run.Severity(tt.result))
+		})
+	}
+}
+
+func TestResult_Severity(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   sarif.Result
+		expected sarif.Severity
+	}{
+		{name: "level", result: sarif.Result{Level: "warning"}, expected: sarif.SeverityMedium},
+		{name: "policy severity", result: sarif.Result{Level: "note", Properties: sarif.ResultProperties{Policy: &sarif.SnykPolicyV1{Severity: "critical"}}}, expected: sarif.SeverityCritical},
+		{name: "policy severity in mixed case", result: sarif.Result{Level: "note", Properties: sarif.ResultProperties{Policy: &sarif.SnykPolicyV1{Severity: "High"}}}, expected: sarif.SeverityHigh},
+		{name: "empty policy severity", result: sarif.Result{Level: "note", Properties: sarif.ResultProperties{Policy: &sarif.SnykPolicyV1{}}}, expected: sarif.SeverityLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.result.Severity())
+		})
+	}
+}
+
+func TestResult_IsSuppressed(t *testing.T) {
+	tests := []struct {
+		name         string
+		suppressions []sarif.Suppression
+		expected     bool
+	}{
+		{name: "no suppressions", expected: false},
+		{name: "accepted", suppressions: []sarif.Suppression{{Status: sarif.Accepted}}, expected: true},
+		{name: "without status", suppressions: []sarif.Suppression{{}}, expected: true},
+		{name: "under review", suppressions: []sarif.Suppression{{Status: sarif.UnderReview}}, expected: false},
+		{name: "rejected", suppressions: []sarif.Suppression{{Status: sarif.Rejected}}, expected: false},
+		{name: "rejected and accepted", suppressions: []sarif.Suppression{{Status: sarif.Rejected}, {Status: sarif.Accepted}}, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sarif.Result{Suppressions: tt.suppressions}.IsSuppressed())
+		})
+	}
+}
+
+func TestSarifDocument_Results(t *testing.T) {
+	document := sarif.SarifDocument{Runs: []sarif.Run{
+		{Results: []sarif.Result{{RuleID: "first"}}},
+		{Results: []sarif.Result{{RuleID: "second"}, {RuleID: "third"}}},
+	}}
+
+	results := document.Results()
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "third", results[2].RuleID)
+}
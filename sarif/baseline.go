@@ -0,0 +1,156 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif
+
+import (
+	"slices"
+	"strings"
+)
+
+// ClassifiedResults splits the results of a scan into the ones introduced by a change and the ones that existed before.
+type ClassifiedResults struct {
+	New      []Result
+	Existing []Result
+}
+
+// ClassifyByBaselineState classifies the results of all runs by their baseline state. Unchanged and updated
+// results are existing ones, absent results are fixed and therefore skipped. Results without a baseline state
+// are treated as new.
+func ClassifyByBaselineState(document SarifDocument) ClassifiedResults {
+	classified := ClassifiedResults{}
+	for _, run := range document.Runs {
+		for _, result := range run.Results {
+			switch result.BaselineState {
+			case BaselineStateUnchanged, BaselineStateUpdated:
+				classified.Existing = append(classified.Existing, result)
+			case BaselineStateAbsent:
+				continue
+			default:
+				classified.New = append(classified.New, result)
+			}
+		}
+	}
+	return classified
+}
+
+// BaselineComparison is the outcome of comparing a document with a baseline.
+type BaselineComparison struct {
+	// Current is a copy of the current document with the baseline state of every result set to new or unchanged.
+	Current   SarifDocument
+	New       []Result
+	Unchanged []Result
+	// Fixed holds the results of the baseline that are no longer found. Their baseline state is absent.
+	Fixed []Result
+}
+
+// matchKeys returns the keys that identify a result across scans, from the most to the least reliable one. A key is
+// empty if the result does not have the fingerprint. Results without fingerprints are matched by their rule, file and
+// message, which do not change when code is moved.
+func matchKeys(result Result) []string {
+	uri := ""
+	if location, ok := result.PrimaryLocation(); ok {
+		uri = location.ArtifactLocation.URI
+	}
+	return []string{
+		result.Fingerprints.SnykAssetFindingV1,
+		result.Fingerprints.SnykOrgProjectFindingV1,
+		result.Fingerprints.Identity,
+		result.Fingerprints.Num0,
+		strings.Join([]string{result.RuleID, uri, result.Message.Text}, "\x00"),
+	}
+}
+
+// conflicting reports whether the results have different fingerprints of a more reliable level than the given one.
+// Such results are different findings, even if their less reliable keys are the same.
+func conflicting(baselineKeys []string, currentKeys []string, level int) bool {
+	for higher := range level {
+		if baselineKeys[higher] != "" && currentKeys[higher] != "" && baselineKeys[higher] != currentKeys[higher] {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareWithBaseline classifies the results of the current document as new or unchanged compared to the baseline,
+// and the results of the baseline that are no longer found as fixed. Results are matched by their fingerprints, the
+// more reliable ones first, and every result of the baseline is matched at most once. A less reliable key is only used
+// if the results do not have different values for any of the more reliable fingerprints.
+func CompareWithBaseline(baseline SarifDocument, current SarifDocument) BaselineComparison {
+	baselineResults := baseline.Results()
+	currentResults := current.Results()
+	baselineKeys := make([][]string, len(baselineResults))
+	for i, result := range baselineResults {
+		baselineKeys[i] = matchKeys(result)
+	}
+	currentKeys := make([][]string, len(currentResults))
+	for i, result := range currentResults {
+		currentKeys[i] = matchKeys(result)
+	}
+
+	baselineMatched := make([]bool, len(baselineResults))
+	currentMatched := make([]bool, len(currentResults))
+	levels := len(matchKeys(Result{}))
+	for level := range levels {
+		candidates := map[string][]int{}
+		for i, keys := range baselineKeys {
+			if !baselineMatched[i] && keys[level] != "" {
+				candidates[keys[level]] = append(candidates[keys[level]], i)
+			}
+		}
+		for i, keys := range currentKeys {
+			if currentMatched[i] || keys[level] == "" {
+				continue
+			}
+			remaining := candidates[keys[level]]
+			j := slices.IndexFunc(remaining, func(b int) bool {
+				return !baselineMatched[b] && !conflicting(baselineKeys[b], keys, level)
+			})
+			if j < 0 {
+				continue
+			}
+			baselineMatched[remaining[j]] = true
+			currentMatched[i] = true
+		}
+	}
+
+	comparison := BaselineComparison{Current: current}
+	comparison.Current.Runs = make([]Run, 0, len(current.Runs))
+	i := 0
+	for _, run := range current.Runs {
+		results := make([]Result, 0, len(run.Results))
+		for _, result := range run.Results {
+			if currentMatched[i] {
+				result.BaselineState = BaselineStateUnchanged
+				comparison.Unchanged = append(comparison.Unchanged, result)
+			} else {
+				result.BaselineState = BaselineStateNew
+				comparison.New = append(comparison.New, result)
+			}
+			results = append(results, result)
+			i++
+		}
+		run.Results = results
+		comparison.Current.Runs = append(comparison.Current.Runs, run)
+	}
+
+	for i, result := range baselineResults {
+		if !baselineMatched[i] {
+			result.BaselineState = BaselineStateAbsent
+			comparison.Fixed = append(comparison.Fixed, result)
+		}
+	}
+	return comparison
+}
@@ -0,0 +1,190 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/snyk/code-client-go/sarif"
+)
+
+func TestClassifyByBaselineState(t *testing.T) {
+	document := sarif.SarifDocument{
+		Runs: []sarif.Run{
+			{Results: []sarif.Result{
+				{RuleID: "new", BaselineState: sarif.BaselineStateNew},
+				{RuleID: "unchanged", BaselineState: sarif.BaselineStateUnchanged},
+				{RuleID: "fixed", BaselineState: sarif.BaselineStateAbsent},
+			}},
+			{Results: []sarif.Result{
+				{RuleID: "updated", BaselineState: sarif.BaselineStateUpdated},
+				{RuleID: "unknown"},
+			}},
+		},
+	}
+
+	classified := sarif.ClassifyByBaselineState(document)
+
+	var newRules, existingRules []string
+	for _, result := range classified.New {
+		newRules = append(newRules, result.RuleID)
+	}
+	for _, result := range classified.Existing {
+		existingRules = append(existingRules, result.RuleID)
+	}
+	assert.Equal(t, []string{"new", "unknown"}, newRules)
+	assert.Equal(t, []string{"unchanged", "updated"}, existingRules)
+}
+
+func resultAt(ruleId string, uri string, fingerprints sarif.Fingerprints) sarif.Result {
+	return sarif.Result{
+		RuleID:       ruleId,
+		Message:      sarif.ResultMessage{Text: ruleId + " in " + uri},
+		Locations:    []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: uri}}}},
+		Fingerprints: fingerprints,
+	}
+}
+
+func ruleIds(results []sarif.Result) []string {
+	ids := []string{}
+	for _, result := range results {
+		ids = append(ids, result.RuleID)
+	}
+	return ids
+}
+
+func TestCompareWithBaseline(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseline  []sarif.Result
+		current   []sarif.Result
+		new       []string
+		unchanged []string
+		fixed     []string
+	}{
+		{
+			name:      "matched by asset fingerprint although the file moved",
+			baseline:  []sarif.Result{resultAt("sqli", "old.js", sarif.Fingerprints{SnykAssetFindingV1: "a"})},
+			current:   []sarif.Result{resultAt("sqli", "new.js", sarif.Fingerprints{SnykAssetFindingV1: "a"})},
+			new:       []string{},
+			unchanged: []string{"sqli"},
+			fixed:     []string{},
+		},
+		{
+			name:      "matched by identity",
+			baseline:  []sarif.Result{resultAt("sqli", "old.js", sarif.Fingerprints{Identity: "id"})},
+			current:   []sarif.Result{resultAt("sqli", "new.js", sarif.Fingerprints{Identity: "id", Num0: "hash"})},
+			new:       []string{},
+			unchanged: []string{"sqli"},
+			fixed:     []string{},
+		},
+		{
+			name:      "different fingerprints",
+			baseline:  []sarif.Result{resultAt("sqli", "old.js", sarif.Fingerprints{Identity: "old"})},
+			current:   []sarif.Result{resultAt("xss", "new.js", sarif.Fingerprints{Identity: "new"})},
+			new:       []string{"xss"},
+			unchanged: []string{},
+			fixed:     []string{"sqli"},
+		},
+		{
+			name:      "falls back to rule, file and message without fingerprints",
+			baseline:  []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{}), resultAt("xss", "app.js", sarif.Fingerprints{})},
+			current:   []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{}), resultAt("xss", "other.js", sarif.Fingerprints{})},
+			new:       []string{"xss"},
+			unchanged: []string{"sqli"},
+			fixed:     []string{"xss"},
+		},
+		{
+			name:      "every baseline result is matched once",
+			baseline:  []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{})},
+			current:   []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{}), resultAt("sqli", "app.js", sarif.Fingerprints{})},
+			new:       []string{"sqli"},
+			unchanged: []string{"sqli"},
+			fixed:     []string{},
+		},
+		{
+			name:      "different fingerprints are not matched by the fallback",
+			baseline:  []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{SnykAssetFindingV1: "fixed", Num0: "hash"})},
+			current:   []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{SnykAssetFindingV1: "new", Num0: "hash"})},
+			new:       []string{"sqli"},
+			unchanged: []string{},
+			fixed:     []string{"sqli"},
+		},
+		{
+			name:      "falls back when only one side has the fingerprint",
+			baseline:  []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{Num0: "hash"})},
+			current:   []sarif.Result{resultAt("sqli", "app.js", sarif.Fingerprints{SnykAssetFindingV1: "asset", Num0: "hash"})},
+			new:       []string{},
+			unchanged: []string{"sqli"},
+			fixed:     []string{},
+		},
+		{
+			name: "fingerprints take precedence over the fallback",
+			baseline: []sarif.Result{
+				resultAt("sqli", "app.js", sarif.Fingerprints{Identity: "second"}),
+			},
+			current: []sarif.Result{
+				resultAt("sqli", "app.js", sarif.Fingerprints{Identity: "first"}),
+				resultAt("sqli", "app.js", sarif.Fingerprints{Identity: "second"}),
+			},
+			new:       []string{"sqli"},
+			unchanged: []string{"sqli"},
+			fixed:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := sarif.SarifDocument{Runs: []sarif.Run{{Results: tt.baseline}}}
+			current := sarif.SarifDocument{Runs: []sarif.Run{{Results: tt.current}}}
+
+			comparison := sarif.CompareWithBaseline(baseline, current)
+
+			assert.Equal(t, tt.new, ruleIds(comparison.New))
+			assert.Equal(t, tt.unchanged, ruleIds(comparison.Unchanged))
+			assert.Equal(t, tt.fixed, ruleIds(comparison.Fixed))
+			for _, result := range comparison.Fixed {
+				assert.Equal(t, sarif.BaselineStateAbsent, result.BaselineState)
+			}
+
+			// the baseline states of the current document agree with the comparison
+			classified := sarif.ClassifyByBaselineState(comparison.Current)
+			assert.Len(t, classified.New, len(tt.new))
+			assert.Len(t, classified.Existing, len(tt.unchanged))
+		})
+	}
+}
+
+func TestCompareWithBaseline_Documents(t *testing.T) {
+	baseline := loadDocument(t)
+	current := loadDocument(t)
+	current.Runs[0].Results = current.Runs[0].Results[1:]
+	current.Runs[0].Results[0].Fingerprints = sarif.Fingerprints{Identity: "new-identity"}
+	current.Runs[0].Results[0].Message.Text = "https.request uses a weak cipher"
+
+	comparison := sarif.CompareWithBaseline(baseline, current)
+
+	assert.Len(t, comparison.New, 1)
+	assert.Len(t, comparison.Unchanged, 4)
+	require.Len(t, comparison.Fixed, 2)
+	assert.Equal(t, sarif.BaselineStateNew, comparison.Current.Runs[0].Results[0].BaselineState)
+	assert.Equal(t, sarif.BaselineStateUnchanged, comparison.Current.Runs[0].Results[1].BaselineState)
+	// the documents are left untouched
+	assert.Empty(t, current.Runs[0].Results[0].BaselineState)
+	assert.Len(t, baseline.Runs[0].Results, 6)
+}
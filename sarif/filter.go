@@ -0,0 +1,217 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// ParseSeverity parses a severity threshold, e.g. "high".
+func ParseSeverity(severity string) (Severity, error) {
+	parsed := Severity(strings.ToLower(severity))
+	if _, ok := severityRanks[parsed]; !ok {
+		return "", fmt.Errorf("invalid severity %q, expected one of low, medium, high or critical", severity)
+	}
+	return parsed, nil
+}
+
+// Filter removes results from SARIF documents. Rules without any remaining results are removed as well. A new Filter
+// keeps all results.
+type Filter struct {
+	withoutSuppressed bool
+	minimumSeverity   Severity
+	includeRules      []string
+	excludeRules      []string
+	includeCWEs       []string
+	excludeCWEs       []string
+	includePaths      []gitignore.Pattern
+	excludePaths      []gitignore.Pattern
+}
+
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// WithoutSuppressed removes results that are suppressed, e.g. ignored in Snyk.
+func (f *Filter) WithoutSuppressed() *Filter {
+	f.withoutSuppressed = true
+	return f
+}
+
+// WithMinimumSeverity removes results below the severity. Results without a severity are removed as well.
+func (f *Filter) WithMinimumSeverity(severity Severity) *Filter {
+	f.minimumSeverity = severity
+	return f
+}
+
+// IncludeRules keeps only results of the rules with the given ids.
+func (f *Filter) IncludeRules(ruleIds ...string) *Filter {
+	f.includeRules = append(f.includeRules, ruleIds...)
+	return f
+}
+
+// ExcludeRules removes results of the rules with the given ids.
+func (f *Filter) ExcludeRules(ruleIds ...string) *Filter {
+	f.excludeRules = append(f.excludeRules, ruleIds...)
+	return f
+}
+
+// IncludeCWEs keeps only results whose rule has at least one of the CWEs, e.g. "CWE-89".
+func (f *Filter) IncludeCWEs(cwes ...string) *Filter {
+	f.includeCWEs = append(f.includeCWEs, cwes...)
+	return f
+}
+
+// ExcludeCWEs removes results whose rule has any of the CWEs.
+func (f *Filter) ExcludeCWEs(cwes ...string) *Filter {
+	f.excludeCWEs = append(f.excludeCWEs, cwes...)
+	return f
+}
+
+// IncludePaths keeps only results whose primary location matches one of the gitignore-style globs, e.g. "src/**".
+func (f *Filter) IncludePaths(globs ...string) *Filter {
+	f.includePaths = append(f.includePaths, parsePatterns(globs)...)
+	return f
+}
+
+// ExcludePaths removes results whose primary location matches any of the gitignore-style globs.
+func (f *Filter) ExcludePaths(globs ...string) *Filter {
+	f.excludePaths = append(f.excludePaths, parsePatterns(globs)...)
+	return f
+}
+
+// Apply returns a copy of the document with the filtered results. The rule indexes of the results are rewritten to
+// match the remaining rules, all other properties of the runs, e.g. the coverage, are preserved.
+func (f *Filter) Apply(document SarifDocument) SarifDocument {
+	filtered := document
+	filtered.Runs = make([]Run, 0, len(document.Runs))
+	for _, run := range document.Runs {
+		filtered.Runs = append(filtered.Runs, f.applyToRun(run))
+	}
+	return filtered
+}
+
+// ApplyResponse returns a copy of the response with the filtered document.
+func (f *Filter) ApplyResponse(response SarifResponse) SarifResponse {
+	filtered := response
+	filtered.Sarif = f.Apply(response.Sarif)
+	return filtered
+}
+
+func (f *Filter) applyToRun(run Run) Run {
+	results := make([]Result, 0, len(run.Results))
+	referencedRules := map[string]bool{}
+	for _, result := range run.Results {
+		if !f.keep(run, result) {
+			continue
+		}
+		results = append(results, result)
+		referencedRules[result.RuleID] = true
+	}
+
+	rules := make([]Rule, 0, len(referencedRules))
+	ruleIndexes := map[string]int{}
+	for _, rule := range run.Tool.Driver.Rules {
+		if referencedRules[rule.ID] {
+			ruleIndexes[rule.ID] = len(rules)
+			rules = append(rules, rule)
+		}
+	}
+
+	for i := range results {
+		ruleIndex, ok := ruleIndexes[results[i].RuleID]
+		if !ok {
+			// -1 is the SARIF default for results that do not reference a rule
+			ruleIndex = -1
+		}
+		results[i].RuleIndex = ruleIndex
+	}
+
+	filtered := run
+	filtered.Results = results
+	filtered.Tool.Driver.Rules = rules
+	return filtered
+}
+
+func (f *Filter) keep(run Run, result Result) bool {
+	if f.withoutSuppressed && result.IsSuppressed() {
+		return false
+	}
+	if f.minimumSeverity != "" && severityRanks[run.Severity(result)] < severityRanks[f.minimumSeverity] {
+		return false
+	}
+	if len(f.includeRules) > 0 && !slices.Contains(f.includeRules, result.RuleID) {
+		return false
+	}
+	if slices.Contains(f.excludeRules, result.RuleID) {
+		return false
+	}
+
+	cwes := run.CWEs(result)
+	if len(f.includeCWEs) > 0 && !containsAny(f.includeCWEs, cwes) {
+		return false
+	}
+	if containsAny(f.excludeCWEs, cwes) {
+		return false
+	}
+
+	location, hasLocation := result.PrimaryLocation()
+	if len(f.includePaths) > 0 && (!hasLocation || !matchesAny(f.includePaths, location.ArtifactLocation.URI)) {
+		return false
+	}
+	if hasLocation && matchesAny(f.excludePaths, location.ArtifactLocation.URI) {
+		return false
+	}
+	return true
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if slices.ContainsFunc(values, func(value string) bool { return strings.EqualFold(value, candidate) }) {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePatterns(globs []string) []gitignore.Pattern {
+	patterns := make([]gitignore.Pattern, 0, len(globs))
+	for _, glob := range globs {
+		patterns = append(patterns, gitignore.ParsePattern(glob, nil))
+	}
+	return patterns
+}
+
+func matchesAny(patterns []gitignore.Pattern, uri string) bool {
+	path := strings.Split(strings.TrimPrefix(uri, "/"), "/")
+	for _, pattern := range patterns {
+		if pattern.Match(path, false) == gitignore.Exclude {
+			return true
+		}
+	}
+	return false
+}
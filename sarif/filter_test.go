@@ -0,0 +1,134 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/snyk/code-client-go/sarif"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		expected sarif.Severity
+		wantErr  bool
+	}{
+		{severity: "low", expected: sarif.SeverityLow},
+		{severity: "medium", expected: sarif.SeverityMedium},
+		{severity: "HIGH", expected: sarif.SeverityHigh},
+		{severity: "critical", expected: sarif.SeverityCritical},
+		{severity: "urgent", wantErr: true},
+		{severity: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			severity, err := sarif.ParseSeverity(tt.severity)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, severity)
+		})
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	document := loadDocument(t)
+
+	// the results are identified by their start lines
+	tests := []struct {
+		name       string
+		filter     *sarif.Filter
+		startLines []int
+	}{
+		{name: "no filters", filter: sarif.NewFilter(), startLines: []int{4, 8, 58, 60, 72, 76}},
+		{name: "without suppressed", filter: sarif.NewFilter().WithoutSuppressed(), startLines: []int{4, 8, 60, 72, 76}},
+		{name: "minimum severity high", filter: sarif.NewFilter().WithMinimumSeverity(sarif.SeverityHigh), startLines: []int{4, 76}},
+		{name: "minimum severity medium", filter: sarif.NewFilter().WithMinimumSeverity(sarif.SeverityMedium), startLines: []int{4, 8, 58, 60, 76}},
+		{name: "minimum severity critical", filter: sarif.NewFilter().WithMinimumSeverity(sarif.SeverityCritical), startLines: []int{}},
+		{name: "include rules", filter: sarif.NewFilter().IncludeRules("javascript/HttpToHttps"), startLines: []int{8, 58, 60}},
+		{name: "exclude rules", filter: sarif.NewFilter().ExcludeRules("javascript/HttpToHttps"), startLines: []int{4, 72, 76}},
+		{name: "include CWEs", filter: sarif.NewFilter().IncludeCWEs("cwe-319"), startLines: []int{8, 58, 60}},
+		{name: "exclude CWEs", filter: sarif.NewFilter().ExcludeCWEs("CWE-547"), startLines: []int{8, 58, 60}},
+		{name: "include paths", filter: sarif.NewFilter().IncludePaths("src/**", "*.service-spec.ts"), startLines: []int{58, 60, 72, 76}},
+		{name: "exclude paths", filter: sarif.NewFilter().ExcludePaths("test/", "scripts/db/migrations"), startLines: []int{8, 58, 60}},
+		{
+			name: "combined",
+			filter: sarif.NewFilter().
+				WithoutSuppressed().
+				WithMinimumSeverity(sarif.SeverityMedium).
+				ExcludePaths("scripts/**"),
+			startLines: []int{60, 76},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := tt.filter.Apply(document)
+
+			require.Len(t, filtered.Runs, 1)
+			run := filtered.Runs[0]
+			startLines := []int{}
+			for _, result := range run.Results {
+				location, ok := result.PrimaryLocation()
+				require.True(t, ok)
+				startLines = append(startLines, location.Region.StartLine)
+
+				// the rule indexes reference the remaining rules
+				require.Less(t, result.RuleIndex, len(run.Tool.Driver.Rules))
+				assert.Equal(t, result.RuleID, run.Tool.Driver.Rules[result.RuleIndex].ID)
+			}
+			assert.Equal(t, tt.startLines, startLines)
+			assert.Equal(t, document.Runs[0].Properties, run.Properties)
+		})
+	}
+}
+
+func TestFilter_Apply_RemovesUnreferencedRules(t *testing.T) {
+	document := loadDocument(t)
+
+	filtered := sarif.NewFilter().IncludeRules("javascript/HardcodedNonCryptoSecret/test").Apply(document)
+
+	rules := filtered.Runs[0].Tool.Driver.Rules
+	require.Len(t, rules, 1)
+	assert.Equal(t, "javascript/HardcodedNonCryptoSecret/test", rules[0].ID)
+	for _, result := range filtered.Runs[0].Results {
+		assert.Equal(t, 0, result.RuleIndex)
+	}
+	// the original document is left untouched
+	assert.Len(t, document.Runs[0].Tool.Driver.Rules, 3)
+	assert.Len(t, document.Runs[0].Results, 6)
+	assert.Equal(t, 2, document.Runs[0].Results[4].RuleIndex)
+}
+
+func TestFilter_ApplyResponse(t *testing.T) {
+	response := sarif.SarifResponse{
+		Status:   "COMPLETE",
+		Coverage: []sarif.SarifCoverage{{Files: 3, IsSupported: true, Lang: "JavaScript"}},
+		Sarif:    loadDocument(t),
+	}
+
+	filtered := sarif.NewFilter().WithMinimumSeverity(sarif.SeverityHigh).ApplyResponse(response)
+
+	assert.Equal(t, "COMPLETE", filtered.Status)
+	assert.Equal(t, response.Coverage, filtered.Coverage)
+	assert.Len(t, filtered.Sarif.Runs[0].Results, 2)
+	assert.Len(t, response.Sarif.Runs[0].Results, 6)
+}
@@ -0,0 +1,200 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SchemaURI and Version identify the SARIF 2.1.0 documents produced by Merge.
+const (
+	SchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	Version   = "2.1.0"
+)
+
+// MergeInput is the response of a scan of a sub-root.
+type MergeInput struct {
+	// Root is the path of the scanned sub-root relative to the common root, e.g. "services/api". The artifact URIs of
+	// the response are rebased onto it.
+	Root     string
+	Response SarifResponse
+}
+
+// Merge combines the responses of several scans into one response. The runs of the same tool are merged into a single
+// run, and the coverage is summed up per language. The merged response is complete if all responses are.
+//
+// The rules of a merged run are deduplicated by their id, the first definition of a rule wins. Other run properties,
+// e.g. the upload result, are taken from the first run that has them, as a run cannot reference more than one
+// project.
+func Merge(inputs ...MergeInput) SarifResponse {
+	merged := SarifResponse{
+		Type:     "sarif",
+		Progress: 1,
+		Status:   "COMPLETE",
+		Sarif: SarifDocument{
+			Schema:  SchemaURI,
+			Version: Version,
+			Runs:    []Run{},
+		},
+	}
+
+	runIndexes := map[string]int{}
+	var runMergers []*runMerger
+	coverageIndexes := map[SarifCoverage]int{}
+	for _, input := range inputs {
+		response := input.Response
+		if merged.Status == "COMPLETE" && response.Status != "COMPLETE" {
+			merged.Status = response.Status
+		}
+		merged.Progress = min(merged.Progress, response.Progress)
+		merged.Timing.FetchingCode += response.Timing.FetchingCode
+		merged.Timing.Queue += response.Timing.Queue
+		merged.Timing.Analysis += response.Timing.Analysis
+
+		for _, coverage := range response.Coverage {
+			key := SarifCoverage{Lang: coverage.Lang, IsSupported: coverage.IsSupported}
+			if i, ok := coverageIndexes[key]; ok {
+				merged.Coverage[i].Files += coverage.Files
+				continue
+			}
+			coverageIndexes[key] = len(merged.Coverage)
+			merged.Coverage = append(merged.Coverage, coverage)
+		}
+
+		for _, run := range response.Sarif.Runs {
+			i, ok := runIndexes[run.Tool.Driver.Name]
+			if !ok {
+				i = len(runMergers)
+				runIndexes[run.Tool.Driver.Name] = i
+				runMergers = append(runMergers, newRunMerger(run))
+			}
+			runMergers[i].add(input.Root, run)
+		}
+	}
+
+	for _, merger := range runMergers {
+		merged.Sarif.Runs = append(merged.Sarif.Runs, merger.run)
+	}
+	return merged
+}
+
+type runMerger struct {
+	run             Run
+	ruleIndexes     map[string]int
+	coverageIndexes map[runCoverageKey]int
+}
+
+type runCoverageKey struct {
+	lang        string
+	kind        string
+	isSupported bool
+}
+
+func newRunMerger(run Run) *runMerger {
+	merged := Run{Tool: run.Tool, Results: []Result{}, Properties: run.Properties}
+	merged.Tool.Driver.Rules = []Rule{}
+	merged.Properties.Coverage = nil
+	return &runMerger{
+		run:             merged,
+		ruleIndexes:     map[string]int{},
+		coverageIndexes: map[runCoverageKey]int{},
+	}
+}
+
+func (m *runMerger) add(root string, run Run) {
+	for _, rule := range run.Tool.Driver.Rules {
+		if _, ok := m.ruleIndexes[rule.ID]; !ok {
+			m.ruleIndexes[rule.ID] = len(m.run.Tool.Driver.Rules)
+			m.run.Tool.Driver.Rules = append(m.run.Tool.Driver.Rules, rule)
+		}
+	}
+
+	for _, result := range run.Results {
+		result = rebaseResult(root, result)
+		ruleIndex, ok := m.ruleIndexes[result.RuleID]
+		if !ok {
+			// -1 is the SARIF default for results that do not reference a rule
+			ruleIndex = -1
+		}
+		result.RuleIndex = ruleIndex
+		m.run.Results = append(m.run.Results, result)
+	}
+
+	if m.run.Properties.UploadResult == (RunProperties{}).UploadResult {
+		m.run.Properties.UploadResult = run.Properties.UploadResult
+	}
+
+	for _, coverage := range run.Properties.Coverage {
+		key := runCoverageKey{lang: coverage.Lang, kind: coverage.Type, isSupported: coverage.IsSupported}
+		if i, ok := m.coverageIndexes[key]; ok {
+			m.run.Properties.Coverage[i].Files += coverage.Files
+			continue
+		}
+		m.coverageIndexes[key] = len(m.run.Properties.Coverage)
+		m.run.Properties.Coverage = append(m.run.Properties.Coverage, coverage)
+	}
+}
+
+// rebaseResult returns a copy of the result whose artifact URIs are relative to the common root instead of the root of
+// the scan.
+func rebaseResult(root string, result Result) Result {
+	result.Locations = rebaseLocations(root, result.Locations)
+
+	codeFlows := make([]CodeFlow, 0, len(result.CodeFlows))
+	for _, codeFlow := range result.CodeFlows {
+		threadFlows := make([]ThreadFlow, 0, len(codeFlow.ThreadFlows))
+		for _, threadFlow := range codeFlow.ThreadFlows {
+			locations := make([]ThreadFlowLocation, 0, len(threadFlow.Locations))
+			for _, location := range threadFlow.Locations {
+				location.Location = rebaseLocation(root, location.Location)
+				locations = append(locations, location)
+			}
+			threadFlows = append(threadFlows, ThreadFlow{Locations: locations})
+		}
+		codeFlows = append(codeFlows, CodeFlow{ThreadFlows: threadFlows})
+	}
+	result.CodeFlows = codeFlows
+	return result
+}
+
+func rebaseLocations(root string, locations []Location) []Location {
+	rebased := make([]Location, 0, len(locations))
+	for _, location := range locations {
+		rebased = append(rebased, rebaseLocation(root, location))
+	}
+	return rebased
+}
+
+// scanRootURIBaseID is the uriBaseId of artifact locations that are relative to the root of the scan.
+const scanRootURIBaseID = "%SRCROOT%"
+
+// rebaseLocation rebases the URI of the location onto the root. URIs relative to another uriBaseId than the root of
+// the scan are left untouched, as they are resolved by the consumer of the document.
+func rebaseLocation(root string, location Location) Location {
+	uri := location.PhysicalLocation.ArtifactLocation.URI
+	uriBaseID := location.PhysicalLocation.ArtifactLocation.URIBaseID
+	if uriBaseID != "" && uriBaseID != scanRootURIBaseID {
+		return location
+	}
+	// absolute paths and URIs with a scheme, e.g. file:///, do not depend on the root of the scan
+	if root == "" || uri == "" || strings.HasPrefix(uri, "/") || strings.Contains(uri, "://") {
+		return location
+	}
+	location.PhysicalLocation.ArtifactLocation.URI = path.Join(filepath.ToSlash(root), uri)
+	return location
+}
@@ -0,0 +1,183 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/snyk/code-client-go/sarif"
+)
+
+func TestMerge(t *testing.T) {
+	api := sarif.SarifResponse{
+		Status:   "COMPLETE",
+		Progress: 1,
+		Coverage: []sarif.SarifCoverage{{Files: 3, IsSupported: true, Lang: "JavaScript"}},
+		Sarif:    loadDocument(t),
+	}
+	api.Timing.Analysis = 100
+	web := sarif.SarifResponse{
+		Status:   "COMPLETE",
+		Progress: 1,
+		Coverage: []sarif.SarifCoverage{
+			{Files: 2, IsSupported: true, Lang: "JavaScript"},
+			{Files: 1, IsSupported: true, Lang: "Java"},
+		},
+		Sarif: loadDocument(t),
+	}
+	web.Timing.Analysis = 50
+	// the second scan only knows the last rule, so that its rule indexes have to be remapped
+	web.Sarif.Runs[0].Tool.Driver.Rules = web.Sarif.Runs[0].Tool.Driver.Rules[2:]
+	web.Sarif.Runs[0].Results = web.Sarif.Runs[0].Results[4:]
+	for i := range web.Sarif.Runs[0].Results {
+		web.Sarif.Runs[0].Results[i].RuleIndex = 0
+	}
+
+	merged := sarif.Merge(
+		sarif.MergeInput{Root: "services/api", Response: api},
+		sarif.MergeInput{Root: "services/web", Response: web},
+	)
+
+	assert.Equal(t, "COMPLETE", merged.Status)
+	assert.Equal(t, 150, merged.Timing.Analysis)
+	assert.Equal(t, []sarif.SarifCoverage{
+		{Files: 5, IsSupported: true, Lang: "JavaScript"},
+		{Files: 1, IsSupported: true, Lang: "Java"},
+	}, merged.Coverage)
+
+	assert.Equal(t, sarif.SchemaURI, merged.Sarif.Schema)
+	assert.Equal(t, sarif.Version, merged.Sarif.Version)
+	require.Len(t, merged.Sarif.Runs, 1)
+	run := merged.Sarif.Runs[0]
+	assert.Equal(t, "SnykCode", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 3)
+	require.Len(t, run.Results, 8)
+	for _, result := range run.Results {
+		assert.Equal(t, result.RuleID, run.Tool.Driver.Rules[result.RuleIndex].ID)
+	}
+
+	apiResult := run.Results[0]
+	assert.Equal(t, "services/api/scripts/db/migrations/20230811153738_add_generated_grouping_columns_to_collections_table.ts", apiResult.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "services/api/scripts/db/migrations/20230811153738_add_generated_grouping_columns_to_collections_table.ts", apiResult.CodeFlows[0].ThreadFlows[0].Locations[0].Location.PhysicalLocation.ArtifactLocation.URI)
+	webResult := run.Results[6]
+	assert.Equal(t, 2, webResult.RuleIndex)
+	assert.Equal(t, "services/web/test/service-tests/service-utils/knex.service-spec.ts", webResult.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	// the coverage of the runs is summed up as well
+	require.Len(t, run.Properties.Coverage, 3)
+	assert.Equal(t, 6, run.Properties.Coverage[0].Files)
+	assert.Equal(t, 730, run.Properties.Coverage[1].Files)
+
+	// the responses are left untouched
+	assert.Equal(t, "scripts/db/cloudsqlproxy-quit.ts", api.Sarif.Runs[0].Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "test/service-tests/service-utils/knex.service-spec.ts", web.Sarif.Runs[0].Results[0].CodeFlows[0].ThreadFlows[0].Locations[0].Location.PhysicalLocation.ArtifactLocation.URI)
+
+	// the merged document is valid JSON that can be read again
+	content, err := json.Marshal(merged.Sarif)
+	require.NoError(t, err)
+	var decoded sarif.SarifDocument
+	require.NoError(t, json.Unmarshal(content, &decoded))
+	assert.Len(t, decoded.Runs[0].Results, 8)
+}
+
+func TestMerge_Status(t *testing.T) {
+	merged := sarif.Merge(
+		sarif.MergeInput{Response: sarif.SarifResponse{Status: "COMPLETE", Progress: 1}},
+		sarif.MergeInput{Response: sarif.SarifResponse{Status: "ANALYZING", Progress: 0.5}},
+		sarif.MergeInput{Response: sarif.SarifResponse{Status: "COMPLETE", Progress: 1}},
+	)
+
+	assert.Equal(t, "ANALYZING", merged.Status)
+	assert.InDelta(t, 0.5, merged.Progress, 0.001)
+	assert.Empty(t, merged.Sarif.Runs)
+}
+
+func TestMerge_Rules(t *testing.T) {
+	first := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "SnykCode", Rules: []sarif.Rule{
+		{ID: "java/Sqli", Name: "first"},
+	}}}}
+	second := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "SnykCode", Rules: []sarif.Rule{
+		{ID: "java/Xss", Name: "xss"},
+		{ID: "java/Sqli", Name: "second"},
+	}}}, Results: []sarif.Result{{RuleID: "java/Sqli", RuleIndex: 1}}}
+
+	merged := sarif.Merge(
+		sarif.MergeInput{Response: sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{first}}}},
+		sarif.MergeInput{Response: sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{second}}}},
+	)
+
+	rules := merged.Sarif.Runs[0].Tool.Driver.Rules
+	require.Len(t, rules, 2)
+	// the first definition of a rule wins
+	assert.Equal(t, "first", rules[0].Name)
+	assert.Equal(t, "java/Xss", rules[1].ID)
+	assert.Equal(t, 0, merged.Sarif.Runs[0].Results[0].RuleIndex)
+}
+
+func TestMerge_RunProperties(t *testing.T) {
+	withoutUpload := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "SnykCode"}}}
+	withUpload := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "SnykCode"}}}
+	withUpload.Properties.UploadResult.ProjectId = "project"
+	withUpload.Properties.UploadResult.ReportUrl = "https://app.snyk.io/project"
+	otherUpload := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "SnykCode"}}}
+	otherUpload.Properties.UploadResult.ProjectId = "other"
+
+	merged := sarif.Merge(
+		sarif.MergeInput{Response: sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{withoutUpload}}}},
+		sarif.MergeInput{Response: sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{withUpload}}}},
+		sarif.MergeInput{Response: sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{otherUpload}}}},
+	)
+
+	assert.Equal(t, withUpload.Properties.UploadResult, merged.Sarif.Runs[0].Properties.UploadResult)
+}
+
+func TestMerge_RebaseURIs(t *testing.T) {
+	tests := []struct {
+		name      string
+		root      string
+		uri       string
+		uriBaseID string
+		expected  string
+	}{
+		{name: "relative URI", root: "services/api", uri: "src/main.ts", expected: "services/api/src/main.ts"},
+		{name: "relative to the scan root", root: "services/api", uri: "src/main.ts", uriBaseID: "%SRCROOT%", expected: "services/api/src/main.ts"},
+		{name: "relative to another base", root: "services/api", uri: "lib/index.js", uriBaseID: "NODE_MODULES", expected: "lib/index.js"},
+		{name: "without root", root: "", uri: "src/main.ts", expected: "src/main.ts"},
+		{name: "current directory", root: ".", uri: "./src/main.ts", expected: "src/main.ts"},
+		{name: "absolute path", root: "services/api", uri: "/src/main.ts", expected: "/src/main.ts"},
+		{name: "URI with scheme", root: "services/api", uri: "file:///src/main.ts", expected: "file:///src/main.ts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := sarif.SarifResponse{Sarif: sarif.SarifDocument{Runs: []sarif.Run{{
+				Results: []sarif.Result{{
+					RuleID:    "rule",
+					Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: tt.uri, URIBaseID: tt.uriBaseID}}}},
+				}},
+			}}}}
+
+			merged := sarif.Merge(sarif.MergeInput{Root: tt.root, Response: response})
+
+			result := merged.Sarif.Runs[0].Results[0]
+			assert.Equal(t, tt.expected, result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			assert.Equal(t, -1, result.RuleIndex)
+		})
+	}
+}
@@ -108,15 +108,16 @@ type ResultProperties struct {
 }
 
 type Result struct {
-	RuleID       string           `json:"ruleId"`
-	RuleIndex    int              `json:"ruleIndex"`
-	Level        string           `json:"level"`
-	Message      ResultMessage    `json:"message"`
-	Locations    []Location       `json:"locations"`
-	Fingerprints Fingerprints     `json:"fingerprints"`
-	CodeFlows    []CodeFlow       `json:"codeFlows"`
-	Properties   ResultProperties `json:"properties"`
-	Suppressions []Suppression    `json:"suppressions"`
+	RuleID        string           `json:"ruleId"`
+	RuleIndex     int              `json:"ruleIndex"`
+	Level         string           `json:"level"`
+	Message       ResultMessage    `json:"message"`
+	Locations     []Location       `json:"locations"`
+	Fingerprints  Fingerprints     `json:"fingerprints"`
+	CodeFlows     []CodeFlow       `json:"codeFlows"`
+	Properties    ResultProperties `json:"properties"`
+	Suppressions  []Suppression    `json:"suppressions"`
+	BaselineState BaselineState    `json:"baselineState,omitempty"`
 }
 
 type ExampleCommitFix struct {
@@ -210,6 +211,17 @@ type SuppressionProperties struct {
 type Category string
 type SuppresionStatus string
 
+// BaselineState describes how a result relates to a previous scan, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/sarif-v2.1.0-errata01-os-complete.html#_Toc141790883
+type BaselineState string
+
+const (
+	BaselineStateNew       BaselineState = "new"
+	BaselineStateUnchanged BaselineState = "unchanged"
+	BaselineStateUpdated   BaselineState = "updated"
+	BaselineStateAbsent    BaselineState = "absent"
+)
+
 const (
 	WontFix         Category         = "wont-fix"
 	NotVulnerable   Category         = "not-vulnerable"
@@ -0,0 +1,160 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamHandler receives the parts of a SARIF document while it is decoded by DecodeStream.
+// Returning an error from a callback stops the decoding.
+type StreamHandler struct {
+	// OnTool is called with the tool of a run, including its rules. Depending on the order of the document it
+	// may be called after the results of the run.
+	OnTool func(runIndex int, tool Tool) error
+	// OnResult is called for every result of a run.
+	OnResult func(runIndex int, result Result) error
+}
+
+// DecodeStream decodes a SARIF document from r without holding all results in memory. The results are passed to
+// the handler one at a time; the returned document contains everything else, e.g. the rules and the properties of
+// every run, but no results.
+func DecodeStream(r io.Reader, handler StreamHandler) (*SarifDocument, error) {
+	decoder := json.NewDecoder(r)
+	document := &SarifDocument{}
+
+	err := decodeObject(decoder, func(key string) error {
+		switch key {
+		case "$schema":
+			return decoder.Decode(&document.Schema)
+		case "version":
+			return decoder.Decode(&document.Version)
+		case "runs":
+			return decodeArray(decoder, func(index int) error {
+				run, runErr := decodeRun(decoder, index, handler)
+				if runErr != nil {
+					return runErr
+				}
+				document.Runs = append(document.Runs, run)
+				return nil
+			})
+		default:
+			return skipValue(decoder)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func decodeRun(decoder *json.Decoder, index int, handler StreamHandler) (Run, error) {
+	run := Run{}
+	err := decodeObject(decoder, func(key string) error {
+		switch key {
+		case "tool":
+			if err := decoder.Decode(&run.Tool); err != nil {
+				return err
+			}
+			if handler.OnTool != nil {
+				return handler.OnTool(index, run.Tool)
+			}
+			return nil
+		case "results":
+			return decodeArray(decoder, func(int) error {
+				var result Result
+				if err := decoder.Decode(&result); err != nil {
+					return err
+				}
+				if handler.OnResult != nil {
+					return handler.OnResult(index, result)
+				}
+				return nil
+			})
+		case "properties":
+			return decoder.Decode(&run.Properties)
+		default:
+			return skipValue(decoder)
+		}
+	})
+	return run, err
+}
+
+// decodeObject reads a JSON object and calls decodeValue for every key, which must consume the value of the key.
+// A null value is treated as an empty object.
+func decodeObject(decoder *json.Decoder, decodeValue func(key string) error) error {
+	isNull, err := expectDelim(decoder, '{')
+	if err != nil || isNull {
+		return err
+	}
+
+	for decoder.More() {
+		token, tokenErr := decoder.Token()
+		if tokenErr != nil {
+			return tokenErr
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected token %v, expected an object key", token)
+		}
+		if err = decodeValue(key); err != nil {
+			return err
+		}
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// decodeArray reads a JSON array and calls decodeElement for every element, which must consume the element.
+// A null value is treated as an empty array.
+func decodeArray(decoder *json.Decoder, decodeElement func(index int) error) error {
+	isNull, err := expectDelim(decoder, '[')
+	if err != nil || isNull {
+		return err
+	}
+
+	for index := 0; decoder.More(); index++ {
+		if err = decodeElement(index); err != nil {
+			return err
+		}
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) (isNull bool, err error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return false, err
+	}
+	if token == nil {
+		return true, nil
+	}
+	if token != delim {
+		return false, fmt.Errorf("unexpected token %v, expected %v", token, delim)
+	}
+	return false, nil
+}
+
+func skipValue(decoder *json.Decoder) error {
+	var value json.RawMessage
+	return decoder.Decode(&value)
+}
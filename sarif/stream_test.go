@@ -0,0 +1,97 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sarif_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/snyk/code-client-go/sarif"
+)
+
+const streamDocument = `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {"driver": {"name": "SnykCode", "rules": [{"id": "rule-1"}]}},
+      "results": [
+        {"ruleId": "rule-1", "level": "error"},
+        {"ruleId": "rule-1", "level": "warning", "unknown": {"nested": [1, 2]}}
+      ],
+      "properties": {"coverage": [{"files": 3, "isSupported": true, "lang": "Go"}]},
+      "invocations": [{"executionSuccessful": true}]
+    },
+    {
+      "results": [{"ruleId": "rule-2"}],
+      "tool": {"driver": {"name": "SnykSecrets", "rules": [{"id": "rule-2"}]}}
+    },
+    {"results": null}
+  ]
+}`
+
+func TestDecodeStream(t *testing.T) {
+	var tools []string
+	var results []string
+
+	document, err := sarif.DecodeStream(strings.NewReader(streamDocument), sarif.StreamHandler{
+		OnTool: func(runIndex int, tool sarif.Tool) error {
+			tools = append(tools, tool.Driver.Name)
+			return nil
+		},
+		OnResult: func(runIndex int, result sarif.Result) error {
+			results = append(results, result.RuleID+"/"+result.Level)
+			assert.Equal(t, strings.HasSuffix(result.RuleID, "2"), runIndex == 1)
+			return nil
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SnykCode", "SnykSecrets"}, tools)
+	assert.Equal(t, []string{"rule-1/error", "rule-1/warning", "rule-2/"}, results)
+
+	assert.Equal(t, "2.1.0", document.Version)
+	require.Len(t, document.Runs, 3)
+	assert.Nil(t, document.Runs[0].Results)
+	assert.Equal(t, "rule-1", document.Runs[0].Tool.Driver.Rules[0].ID)
+	assert.Equal(t, 3, document.Runs[0].Properties.Coverage[0].Files)
+	assert.Equal(t, "SnykSecrets", document.Runs[1].Tool.Driver.Name)
+}
+
+func TestDecodeStream_StopsOnHandlerError(t *testing.T) {
+	stop := errors.New("stop")
+	count := 0
+
+	_, err := sarif.DecodeStream(strings.NewReader(streamDocument), sarif.StreamHandler{
+		OnResult: func(int, sarif.Result) error {
+			count++
+			return stop
+		},
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, count)
+}
+
+func TestDecodeStream_InvalidDocument(t *testing.T) {
+	_, err := sarif.DecodeStream(strings.NewReader(`{"runs": {}}`), sarif.StreamHandler{})
+
+	assert.Error(t, err)
+}
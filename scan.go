@@ -20,6 +20,9 @@ package codeclient
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -32,23 +35,41 @@ import (
 	"github.com/snyk/code-client-go/internal/analysis"
 	testModels "github.com/snyk/code-client-go/internal/api/test/2025-04-07/models"
 	"github.com/snyk/code-client-go/internal/deepcode"
+	"github.com/snyk/code-client-go/internal/uploadrevision"
 	"github.com/snyk/code-client-go/observability"
 	"github.com/snyk/code-client-go/sarif"
 	"github.com/snyk/code-client-go/scan"
 )
 
 type codeScanner struct {
-	httpClient           codeClientHTTP.HTTPClient
-	bundleManager        bundle.BundleManager
-	analysisOrchestrator analysis.AnalysisOrchestrator
-	instrumentor         observability.Instrumentor
-	errorReporter        observability.ErrorReporter
-	trackerFactory       scan.TrackerFactory
-	logger               *zerolog.Logger
-	config               config.Config
-	resultTypes          testModels.ResultType
+	httpClient            codeClientHTTP.HTTPClient
+	bundleManager         bundle.BundleManager
+	analysisOrchestrator  analysis.AnalysisOrchestrator
+	instrumentor          observability.Instrumentor
+	errorReporter         observability.ErrorReporter
+	trackerFactory        scan.TrackerFactory
+	logger                *zerolog.Logger
+	config                config.Config
+	resultTypes           testModels.ResultType
+	pollingStrategy       scan.PollingStrategy
+	legacyPollingStrategy scan.PollingStrategy
+	summaryOnly           bool
+	apiVersion            string
+	uploader              Uploader
+	legacyRequestContext  analysis.LegacyRequestContext
+	engineType            EngineType
 }
 
+// Uploader selects how the files of a scan are uploaded before they are tested.
+type Uploader string
+
+const (
+	// DeepcodeBundleUploader uploads the files as a deepcode bundle. It is the default.
+	DeepcodeBundleUploader Uploader = "deepcode_bundle"
+	// UploadRevisionUploader uploads the files to an upload revision. Legacy analyses do not support it.
+	UploadRevisionUploader Uploader = "upload_revision"
+)
+
 type CodeScanner interface {
 	Upload(
 		ctx context.Context,
@@ -76,6 +97,7 @@ type CodeScanner interface {
 		files <-chan string,
 		changedFiles map[string]bool,
 		statusChannel chan<- scan.LegacyScanStatus,
+		options ...LegacyAnalysisOption,
 	) (*sarif.SarifResponse, string, error)
 }
 
@@ -118,6 +140,63 @@ func WithTrackerFactory(trackerFactory scan.TrackerFactory) OptionFunc {
 	}
 }
 
+// WithPollingStrategy configures how often the scanner polls for analysis results. It applies to legacy analyses
+// too, which otherwise poll every second.
+func WithPollingStrategy(strategy scan.PollingStrategy) OptionFunc {
+	return func(c *codeScanner) {
+		c.pollingStrategy = strategy
+		c.legacyPollingStrategy = strategy
+	}
+}
+
+// WithSummaryOnly configures a lightweight scanner that does not download the findings of completed tests. Analyses
+// then return a nil SarifResponse and only the result metadata, e.g. the test result and the severity counts.
+func WithSummaryOnly() OptionFunc {
+	return func(c *codeScanner) {
+		c.summaryOnly = true
+	}
+}
+
+// WithApiVersion selects the version of the test API, e.g. "2024-12-21" to keep using an older version. If the
+// version has been sunset, the scanner falls back to an older supported version.
+func WithApiVersion(version string) OptionFunc {
+	return func(c *codeScanner) {
+		c.apiVersion = version
+	}
+}
+
+// WithUploader selects how files are uploaded by Upload and UploadAndAnalyze. It defaults to DeepcodeBundleUploader.
+func WithUploader(uploader Uploader) OptionFunc {
+	return func(c *codeScanner) {
+		c.uploader = uploader
+	}
+}
+
+// WithLegacyInitiator sets what triggered legacy analyses, e.g. "CLI". Without it, the initiator is derived from the
+// scan.ScanSource of the context.
+func WithLegacyInitiator(initiator string) OptionFunc {
+	return func(c *codeScanner) {
+		c.legacyRequestContext.Initiator = initiator
+	}
+}
+
+// WithLegacyFlow sets the name of the flow that legacy analyses are attributed to. It defaults to "language-server".
+func WithLegacyFlow(flow string) OptionFunc {
+	return func(c *codeScanner) {
+		c.legacyRequestContext.Flow = flow
+	}
+}
+
+// WithLegacyOrg describes the organization of legacy analyses. The public id of the organization is always taken from
+// the config.Config. An empty name or display name is sent as "unknown".
+func WithLegacyOrg(name string, displayName string, flags map[string]bool) OptionFunc {
+	return func(c *codeScanner) {
+		c.legacyRequestContext.OrgName = name
+		c.legacyRequestContext.OrgDisplayName = displayName
+		c.legacyRequestContext.OrgFlags = flags
+	}
+}
+
 type AnalysisOption func(*analysis.AnalysisConfig)
 
 func ReportLocalTest(projectName string, targetName string, targetReference string) AnalysisOption {
@@ -135,6 +214,14 @@ func WithProjectTags(tags *[]string) AnalysisOption {
 	}
 }
 
+// WithTestCreatedCallback registers a callback that receives the test id as soon as the test has been created.
+// Persisting the id allows an interrupted analysis to be continued later via ResumeAnalysis.
+func WithTestCreatedCallback(callback func(testId string)) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.OnTestCreated = callback
+	}
+}
+
 func ReportRemoteTest(projectId uuid.UUID, commitId string) AnalysisOption {
 	return func(c *analysis.AnalysisConfig) {
 		c.Report = true
@@ -143,6 +230,137 @@ func ReportRemoteTest(projectId uuid.UUID, commitId string) AnalysisOption {
 	}
 }
 
+// WithGitUrlCoordinates selects a revision of a repository that is accessible through the SCM integration with the
+// given id. It is used by AnalyzeRemoteRevision.
+func WithGitUrlCoordinates(integrationId uuid.UUID, repoUrl string, revision string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.IntegrationId = &integrationId
+		c.RepoUrl = &repoUrl
+		c.Revision = &revision
+	}
+}
+
+// WithDiffTarget selects the base and head version of an SCM target whose changes are tested by AnalyzeDiff.
+func WithDiffTarget(targetId uuid.UUID, baseVersion string, headVersion string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.TargetId = &targetId
+		c.BaseVersion = &baseVersion
+		c.HeadVersion = &headVersion
+	}
+}
+
+// WithWorkspace selects the server-side workspace that is tested by AnalyzeWorkspace.
+func WithWorkspace(workspaceId uuid.UUID) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.WorkspaceId = &workspaceId
+	}
+}
+
+// WithInitiator sets what triggered the test, e.g. "pr_check" for pull request checks or "ide_test". Without it, the
+// initiator is derived from the scan.ScanSource of the context.
+func WithInitiator(initiator string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.Initiator = &initiator
+	}
+}
+
+// WithLabel attaches an arbitrary value to the test.
+func WithLabel(label string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.Label = &label
+	}
+}
+
+// WithLabels attaches arbitrary key-value pairs to the test, e.g. the pipeline id or the owning team. The labels of
+// multiple options are merged; the test service accepts up to 10 keys.
+func WithLabels(labels map[string]string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		merged := map[string]string{}
+		if c.Labels != nil {
+			maps.Copy(merged, *c.Labels)
+		}
+		maps.Copy(merged, labels)
+		c.Labels = &merged
+	}
+}
+
+// WithOrigin sets the source control management system or platform the tested code originates from.
+func WithOrigin(origin string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.Origin = &origin
+	}
+}
+
+// WithStreamHandler passes the results to the handler one at a time while the findings are downloaded, instead of
+// keeping the whole findings document in memory. The returned SarifResponse then contains the runs without results.
+func WithStreamHandler(handler sarif.StreamHandler) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.StreamHandler = &handler
+	}
+}
+
+// WithExclusionGlobs excludes the files and directories matching the given glob patterns from the test.
+func WithExclusionGlobs(globs []string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.ExclusionGlobs = &globs
+	}
+}
+
+// WithScanners restricts the test to the given scanners, e.g. "sast" or "secrets".
+func WithScanners(scanners ...string) AnalysisOption {
+	return func(c *analysis.AnalysisConfig) {
+		c.Scanners = &scanners
+	}
+}
+
+// LegacySeverity is the minimum severity of the findings returned by a legacy analysis.
+type LegacySeverity int
+
+const (
+	LegacySeverityLow    LegacySeverity = 1
+	LegacySeverityMedium LegacySeverity = 2
+	LegacySeverityHigh   LegacySeverity = 3
+)
+
+// ParseLegacySeverity parses a severity threshold as passed to the CLI, see sarif.ParseSeverity. The legacy scanner
+// knows no critical severity, so critical maps to LegacySeverityHigh and the high findings have to be filtered
+// client-side.
+func ParseLegacySeverity(threshold string) (LegacySeverity, error) {
+	severity, err := sarif.ParseSeverity(threshold)
+	if err != nil {
+		return 0, err
+	}
+	switch severity {
+	case sarif.SeverityLow:
+		return LegacySeverityLow, nil
+	case sarif.SeverityMedium:
+		return LegacySeverityMedium, nil
+	default:
+		return LegacySeverityHigh, nil
+	}
+}
+
+type legacyAnalysisConfig struct {
+	severity    LegacySeverity
+	prioritized bool
+}
+
+type LegacyAnalysisOption func(*legacyAnalysisConfig)
+
+// WithLegacySeverity filters the findings of a legacy analysis on the server by their minimum severity.
+func WithLegacySeverity(severity LegacySeverity) LegacyAnalysisOption {
+	return func(c *legacyAnalysisConfig) {
+		c.severity = severity
+	}
+}
+
+// WithLegacyPrioritized requests a prioritized legacy analysis, which returns the first results faster.
+func WithLegacyPrioritized() LegacyAnalysisOption {
+	return func(c *legacyAnalysisConfig) {
+		c.prioritized = true
+	}
+}
+
 // NewCodeScanner creates a Code Scanner which can be used to trigger Snyk Code on a folder.
 func NewCodeScanner(
 	config config.Config,
@@ -155,13 +373,17 @@ func NewCodeScanner(
 	trackerFactory := scan.NewNoopTrackerFactory()
 
 	scanner := &codeScanner{
-		config:         config,
-		httpClient:     httpClient,
-		errorReporter:  errorReporter,
-		logger:         &nopLogger,
-		instrumentor:   instrumentor,
-		trackerFactory: trackerFactory,
-		resultTypes:    testModels.CodeSecurityCodeQuality,
+		config:                config,
+		httpClient:            httpClient,
+		errorReporter:         errorReporter,
+		logger:                &nopLogger,
+		instrumentor:          instrumentor,
+		trackerFactory:        trackerFactory,
+		resultTypes:           testModels.CodeSecurityCodeQuality,
+		pollingStrategy:       scan.NewDefaultPollingStrategy(),
+		legacyPollingStrategy: scan.NewLegacyPollingStrategy(),
+		uploader:              DeepcodeBundleUploader,
+		engineType:            TestServiceEngine,
 	}
 
 	for _, option := range options {
@@ -170,8 +392,13 @@ func NewCodeScanner(
 
 	// initialize other dependencies
 	deepcodeClient := deepcode.NewDeepcodeClient(scanner.config, httpClient, scanner.logger, scanner.instrumentor, scanner.errorReporter)
-	bundleManager := bundle.NewBundleManager(deepcodeClient, scanner.logger, scanner.instrumentor, scanner.errorReporter, scanner.trackerFactory)
-	scanner.bundleManager = bundleManager
+	switch scanner.uploader {
+	case UploadRevisionUploader:
+		uploadRevisionClient := uploadrevision.NewClient(scanner.config, httpClient, scanner.logger, scanner.instrumentor)
+		scanner.bundleManager = bundle.NewUploadRevisionManager(deepcodeClient, uploadRevisionClient, scanner.config, scanner.logger, scanner.instrumentor, scanner.errorReporter, scanner.trackerFactory)
+	default:
+		scanner.bundleManager = bundle.NewBundleManager(deepcodeClient, scanner.logger, scanner.instrumentor, scanner.errorReporter, scanner.trackerFactory)
+	}
 	analysisOrchestrator := analysis.NewAnalysisOrchestrator(
 		scanner.config,
 		httpClient,
@@ -180,6 +407,10 @@ func NewCodeScanner(
 		analysis.WithTrackerFactory(scanner.trackerFactory),
 		analysis.WithLogger(scanner.logger),
 		analysis.WithResultType(scanner.resultTypes),
+		analysis.WithPollingStrategy(scanner.pollingStrategy),
+		analysis.WithSummaryOnly(scanner.summaryOnly),
+		analysis.WithApiVersion(scanner.apiVersion),
+		analysis.WithLegacyRequestContext(scanner.legacyRequestContext),
 	)
 	scanner.analysisOrchestrator = analysisOrchestrator
 
@@ -190,11 +421,18 @@ func NewCodeScanner(
 // It can be used to replace the bundle manager in tests.
 func (c *codeScanner) WithBundleManager(bundleManager bundle.BundleManager) *codeScanner {
 	return &codeScanner{
-		bundleManager:        bundleManager,
-		analysisOrchestrator: c.analysisOrchestrator,
-		errorReporter:        c.errorReporter,
-		logger:               c.logger,
-		config:               c.config,
+		bundleManager:         bundleManager,
+		analysisOrchestrator:  c.analysisOrchestrator,
+		errorReporter:         c.errorReporter,
+		logger:                c.logger,
+		config:                c.config,
+		pollingStrategy:       c.pollingStrategy,
+		legacyPollingStrategy: c.legacyPollingStrategy,
+		summaryOnly:           c.summaryOnly,
+		apiVersion:            c.apiVersion,
+		uploader:              c.uploader,
+		legacyRequestContext:  c.legacyRequestContext,
+		engineType:            c.engineType,
 	}
 }
 
@@ -202,11 +440,18 @@ func (c *codeScanner) WithBundleManager(bundleManager bundle.BundleManager) *cod
 // It can be used to replace the analysis orchestrator in tests.
 func (c *codeScanner) WithAnalysisOrchestrator(analysisOrchestrator analysis.AnalysisOrchestrator) *codeScanner {
 	return &codeScanner{
-		bundleManager:        c.bundleManager,
-		analysisOrchestrator: analysisOrchestrator,
-		errorReporter:        c.errorReporter,
-		logger:               c.logger,
-		config:               c.config,
+		bundleManager:         c.bundleManager,
+		analysisOrchestrator:  analysisOrchestrator,
+		errorReporter:         c.errorReporter,
+		logger:                c.logger,
+		config:                c.config,
+		pollingStrategy:       c.pollingStrategy,
+		legacyPollingStrategy: c.legacyPollingStrategy,
+		summaryOnly:           c.summaryOnly,
+		apiVersion:            c.apiVersion,
+		uploader:              c.uploader,
+		legacyRequestContext:  c.legacyRequestContext,
+		engineType:            c.engineType,
 	}
 }
 
@@ -274,15 +519,24 @@ func (c *codeScanner) UploadAndAnalyzeLegacy(
 	files <-chan string,
 	changedFiles map[string]bool,
 	statusChannel chan<- scan.LegacyScanStatus,
+	options ...LegacyAnalysisOption,
 ) (*sarif.SarifResponse, string, error) {
 	defer close(statusChannel)
+	if c.uploader == UploadRevisionUploader {
+		return nil, "", errors.New("legacy analysis does not support upload revisions")
+	}
 	uploadedBundle, err := c.Upload(ctx, requestId, target, files, changedFiles)
 	if err != nil || uploadedBundle == nil || uploadedBundle.GetBundleHash() == "" {
 		c.logger.Debug().Msg("empty bundle, no Snyk Code analysis")
 		return nil, "", err
 	}
 
-	response, bundleHash, err := c.analyzeLegacy(ctx, uploadedBundle, shardKey, statusChannel)
+	cfg := legacyAnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	response, bundleHash, err := c.analyzeLegacy(ctx, uploadedBundle, shardKey, statusChannel, cfg)
 	return response, bundleHash, err
 }
 
@@ -291,14 +545,15 @@ func (c *codeScanner) analyzeLegacy(
 	bundle bundle.Bundle,
 	shardKey string,
 	statusChannel chan<- scan.LegacyScanStatus,
+	cfg legacyAnalysisConfig,
 ) (*sarif.SarifResponse, string, error) {
 	bundleHash := bundle.GetBundleHash()
 	limitToFiles := bundle.GetLimitToFiles()
-	severity := 0
 
+	backoff := c.legacyPollingStrategy.NewBackoff()
 	start := time.Now()
 	for {
-		response, status, err := c.analysisOrchestrator.RunLegacyTest(ctx, bundleHash, shardKey, limitToFiles, severity)
+		response, status, err := c.analysisOrchestrator.RunLegacyTest(ctx, bundleHash, shardKey, limitToFiles, int(cfg.severity), cfg.prioritized)
 
 		if err != nil {
 			c.logger.Error().Err(err).
@@ -311,12 +566,19 @@ func (c *codeScanner) analyzeLegacy(
 		switch status.Message {
 		case analysis.StatusComplete:
 			c.logger.Trace().Msg("sending diagnostics...")
-			statusChannel <- scan.NewLegacyScanDoneStatus("Analysis complete")
+			done := scan.NewLegacyScanDoneStatus("Analysis complete")
+			done.Timing = status.Timing
+			done.Coverage = status.Coverage
+			statusChannel <- done
 			return response, bundleHash, err
 		case analysis.StatusAnalyzing:
 			c.logger.Trace().Msg("\"Analyzing\" message received")
 		}
 
+		// send the progress right away, so that partial results can be shown while the analysis is still running
+		c.logger.Trace().Msg("sending In-Progress message to client")
+		statusChannel <- status
+
 		if time.Since(start) > c.config.SnykCodeAnalysisTimeout() {
 			err := errors.New("analysis call timed out")
 			c.logger.Error().Err(err).Msg("timeout...")
@@ -324,9 +586,15 @@ func (c *codeScanner) analyzeLegacy(
 			return nil, "", err
 		}
 
-		time.Sleep(1 * time.Second)
-		c.logger.Trace().Msg("sending In-Progress message to client")
-		statusChannel <- status
+		// the last poll happens right at the timeout
+		wait := min(backoff.Next(0), max(c.config.SnykCodeAnalysisTimeout()-time.Since(start), 0))
+		select {
+		case <-ctx.Done():
+			c.logger.Debug().Err(ctx.Err()).Msg("analysis cancelled")
+			statusChannel <- scan.NewLegacyScanDoneStatus("Analysis cancelled")
+			return nil, "", ctx.Err()
+		case <-time.After(wait):
+		}
 	}
 }
 
@@ -359,6 +627,75 @@ func (c *codeScanner) UploadAndAnalyzeWithOptions(
 	return response, uploadedBundle.GetBundleHash(), metadata, err
 }
 
+// UploadAndAnalyzeStream works like UploadAndAnalyzeWithOptions but streams the results to the handler, which keeps
+// the memory usage low for large repositories. The returned SarifResponse contains the rules and properties of
+// every run, but no results.
+func (c *codeScanner) UploadAndAnalyzeStream(
+	ctx context.Context,
+	requestId string,
+	target scan.Target,
+	files <-chan string,
+	changedFiles map[string]bool,
+	handler sarif.StreamHandler,
+	options ...AnalysisOption,
+) (*sarif.SarifResponse, string, *scan.ResultMetaData, error) {
+	options = append(slices.Clone(options), WithStreamHandler(handler))
+	return c.UploadAndAnalyzeWithOptions(ctx, requestId, target, files, changedFiles, options...)
+}
+
+// UploadAndAnalyzeWithSbom uploads the source files and the CycloneDX or SPDX SBOM document and tests both in a single
+// test. The returned SarifResponse contains the findings of the sast component; the ResultMetaData contains the
+// findings of every component, e.g. the CycloneDX document of the SBOM component.
+func (c *codeScanner) UploadAndAnalyzeWithSbom(
+	ctx context.Context,
+	requestId string,
+	target scan.Target,
+	sbomFilePath string,
+	files <-chan string,
+	changedFiles map[string]bool,
+	options ...AnalysisOption,
+) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	sourceBundle, err := c.Upload(ctx, requestId, target, files, changedFiles)
+	if err != nil || sourceBundle == nil || sourceBundle.GetBundleHash() == "" {
+		c.logger.Debug().Msg("empty bundle, no Snyk Code analysis")
+		return nil, nil, err
+	}
+
+	sbomFiles := make(chan string, 1)
+	sbomFiles <- sbomFilePath
+	close(sbomFiles)
+	unfilteredBundleManager, ok := c.bundleManager.(bundle.UnfilteredBundleManager)
+	if !ok {
+		return nil, nil, errors.New("the bundle manager cannot bundle SBOM documents")
+	}
+	sbomBundle, err := unfilteredBundleManager.CreateEmptyUnfiltered(ctx, filepath.Dir(sbomFilePath), sbomFiles)
+	err = c.checkCancellationOrLogError(ctx, sbomFilePath, err, "error creating SBOM bundle...")
+	if err != nil {
+		return nil, nil, err
+	}
+	sbomBundle, err = c.bundleManager.Upload(ctx, requestId, sbomBundle, sbomBundle.GetFiles())
+	err = c.checkCancellationOrLogError(ctx, sbomFilePath, err, "error uploading SBOM bundle...")
+	if err != nil {
+		return nil, nil, err
+	}
+	if sbomBundle.GetBundleHash() == "" {
+		return nil, nil, errors.Errorf("the SBOM %s is empty or too large", sbomFilePath)
+	}
+
+	cfg := analysis.AnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	response, metadata, err := c.analysisOrchestrator.RunTestSbom(ctx, c.config.Organization(), sourceBundle, sbomBundle, target, sbomFilePath, cfg)
+	err = c.checkCancellationOrLogError(ctx, target.GetPath(), err, "error running analysis...")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, metadata, nil
+}
+
 func (c *codeScanner) AnalyzeRemote(ctx context.Context, options ...AnalysisOption) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
 	cfg := analysis.AnalysisConfig{}
 	for _, opt := range options {
@@ -378,3 +715,106 @@ func (c *codeScanner) AnalyzeRemote(ctx context.Context, options ...AnalysisOpti
 
 	return response, metadata, err
 }
+
+// ResumeAnalysis continues polling an existing test, identified by the test id, and retrieves its findings.
+func (c *codeScanner) ResumeAnalysis(ctx context.Context, testId string) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	err := c.checkCancellationOrLogError(ctx, "", nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, metadata, err := c.analysisOrchestrator.ResumeTest(ctx, c.config.Organization(), testId)
+	err = c.checkCancellationOrLogError(ctx, "", err, "error resuming analysis...")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, metadata, err
+}
+
+// GetTestConfiguration retrieves the effective configuration of a test, e.g. to check which scanners and
+// organization settings applied to it.
+func (c *codeScanner) GetTestConfiguration(ctx context.Context, testId string) (*scan.TestConfiguration, error) {
+	err := c.checkCancellationOrLogError(ctx, "", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	configuration, err := c.analysisOrchestrator.GetTestConfiguration(ctx, c.config.Organization(), testId)
+	err = c.checkCancellationOrLogError(ctx, "", err, "error retrieving test configuration...")
+	if err != nil {
+		return nil, err
+	}
+
+	return configuration, nil
+}
+
+// AnalyzeRemoteRevision tests a revision of an SCM-integrated repository without a local checkout. The revision
+// must be selected with WithGitUrlCoordinates.
+func (c *codeScanner) AnalyzeRemoteRevision(ctx context.Context, options ...AnalysisOption) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	cfg := analysis.AnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	err := c.checkCancellationOrLogError(ctx, "", nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	response, metadata, err := c.analysisOrchestrator.RunTestGitUrlCoordinates(ctx, c.config.Organization(), cfg)
+
+	err = c.checkCancellationOrLogError(ctx, "", err, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, metadata, err
+}
+
+// AnalyzeWorkspace tests the content of a server-side workspace, selected with WithWorkspace, without uploading a
+// bundle.
+func (c *codeScanner) AnalyzeWorkspace(ctx context.Context, options ...AnalysisOption) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	cfg := analysis.AnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	err := c.checkCancellationOrLogError(ctx, "", nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	response, metadata, err := c.analysisOrchestrator.RunTestWorkspace(ctx, c.config.Organization(), cfg)
+
+	err = c.checkCancellationOrLogError(ctx, "", err, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, metadata, err
+}
+
+// AnalyzeDiff tests only the changes between two versions of an SCM target, selected with WithDiffTarget, and
+// classifies the findings into new and existing ones.
+func (c *codeScanner) AnalyzeDiff(ctx context.Context, options ...AnalysisOption) (*sarif.SarifResponse, *sarif.ClassifiedResults, *scan.ResultMetaData, error) {
+	cfg := analysis.AnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	err := c.checkCancellationOrLogError(ctx, "", nil, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	response, metadata, err := c.analysisOrchestrator.RunTestDiff(ctx, c.config.Organization(), cfg)
+
+	err = c.checkCancellationOrLogError(ctx, "", err, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if response == nil {
+		return nil, nil, metadata, nil
+	}
+	classified := sarif.ClassifyByBaselineState(response.Sarif)
+	return response, &classified, metadata, nil
+}
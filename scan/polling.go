@@ -0,0 +1,108 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scan
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// PollingStrategy controls how long to wait between two polls for analysis results.
+// The interval starts at InitialInterval and grows by Multiplier after every poll until it reaches MaxInterval.
+// Jitter is the fraction (0-1) by which each interval is randomly shortened or extended.
+type PollingStrategy struct {
+	InitialInterval time.Duration
+	Multiplier      float64
+	MaxInterval     time.Duration
+	Jitter          float64
+}
+
+func NewDefaultPollingStrategy() PollingStrategy {
+	return PollingStrategy{
+		InitialInterval: 1 * time.Second,
+		Multiplier:      1.5,
+		MaxInterval:     30 * time.Second,
+		Jitter:          0.1,
+	}
+}
+
+// NewLegacyPollingStrategy polls every second, which is how legacy analyses have always been polled.
+func NewLegacyPollingStrategy() PollingStrategy {
+	return PollingStrategy{
+		InitialInterval: 1 * time.Second,
+		Multiplier:      1,
+		MaxInterval:     1 * time.Second,
+	}
+}
+
+// PollingBackoff hands out the intervals of a single polling loop.
+type PollingBackoff struct {
+	strategy PollingStrategy
+	current  time.Duration
+}
+
+// NewBackoff starts a new polling loop. Unset fields of the strategy fall back to the defaults, except for the
+// Jitter, where zero disables the jitter. Values that are out of range are clamped.
+func (s PollingStrategy) NewBackoff() *PollingBackoff {
+	defaults := NewDefaultPollingStrategy()
+	if s.InitialInterval <= 0 {
+		s.InitialInterval = defaults.InitialInterval
+	}
+	if s.Multiplier == 0 {
+		s.Multiplier = defaults.Multiplier
+	} else if s.Multiplier < 1 {
+		s.Multiplier = 1
+	}
+	if s.MaxInterval == 0 {
+		s.MaxInterval = max(defaults.MaxInterval, s.InitialInterval)
+	} else if s.MaxInterval < s.InitialInterval {
+		s.MaxInterval = s.InitialInterval
+	}
+	if s.Jitter < 0 || s.Jitter > 1 {
+		s.Jitter = 0
+	}
+
+	return &PollingBackoff{
+		strategy: s,
+		current:  s.InitialInterval,
+	}
+}
+
+// Next returns the time to wait before the next poll. A retryHint, e.g. from a Retry-After header, is honored even
+// if it exceeds MaxInterval, the computed interval is used if it is longer than the hint.
+func (b *PollingBackoff) Next(retryHint time.Duration) time.Duration {
+	interval := b.current
+	if b.strategy.Jitter > 0 {
+		interval = time.Duration(float64(interval) * (1 + b.strategy.Jitter*(2*rand.Float64()-1)))
+	}
+
+	b.current = min(time.Duration(float64(b.current)*b.strategy.Multiplier), b.strategy.MaxInterval)
+	return max(retryHint, interval)
+}
+
+// ThrottledError is returned when the service asks to repeat a request later, e.g. with the status 429 or 503.
+// RetryAfter is the time to wait that the service requested, or zero if it did not send a Retry-After header.
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e ThrottledError) Error() string {
+	return fmt.Sprintf("request was throttled, retry after %s: %v", e.RetryAfter, e.Err)
+}
+
+func (e ThrottledError) Unwrap() error { return e.Err }
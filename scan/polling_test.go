@@ -0,0 +1,107 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollingBackoff_growsUntilCap(t *testing.T) {
+	backoff := PollingStrategy{
+		InitialInterval: 1 * time.Second,
+		Multiplier:      2,
+		MaxInterval:     5 * time.Second,
+	}.NewBackoff()
+
+	assert.Equal(t, 1*time.Second, backoff.Next(0))
+	assert.Equal(t, 2*time.Second, backoff.Next(0))
+	assert.Equal(t, 4*time.Second, backoff.Next(0))
+	assert.Equal(t, 5*time.Second, backoff.Next(0))
+	assert.Equal(t, 5*time.Second, backoff.Next(0))
+}
+
+func TestPollingBackoff_retryHintTakesPrecedence(t *testing.T) {
+	backoff := NewDefaultPollingStrategy().NewBackoff()
+
+	assert.Equal(t, 7*time.Second, backoff.Next(7*time.Second))
+}
+
+func TestPollingBackoff_retryHintIsNotCapped(t *testing.T) {
+	backoff := NewDefaultPollingStrategy().NewBackoff()
+
+	assert.Equal(t, 5*time.Minute, backoff.Next(5*time.Minute))
+}
+
+func TestPollingBackoff_shorterRetryHintKeepsComputedInterval(t *testing.T) {
+	backoff := PollingStrategy{InitialInterval: 4 * time.Second, Multiplier: 1}.NewBackoff()
+
+	assert.Equal(t, 4*time.Second, backoff.Next(1*time.Second))
+}
+
+func TestPollingBackoff_jitterStaysInBounds(t *testing.T) {
+	backoff := PollingStrategy{
+		InitialInterval: 10 * time.Second,
+		Multiplier:      1,
+		MaxInterval:     10 * time.Second,
+		Jitter:          0.2,
+	}.NewBackoff()
+
+	for i := 0; i < 100; i++ {
+		interval := backoff.Next(0)
+		assert.GreaterOrEqual(t, interval, 8*time.Second)
+		assert.LessOrEqual(t, interval, 12*time.Second)
+	}
+}
+
+func TestPollingBackoff_zeroStrategyUsesDefaults(t *testing.T) {
+	backoff := PollingStrategy{}.NewBackoff()
+
+	assert.Equal(t, NewDefaultPollingStrategy().InitialInterval, backoff.Next(0))
+	assert.Equal(t, 1500*time.Millisecond, backoff.Next(0))
+}
+
+func TestPollingBackoff_partialStrategyUsesDefaultsForUnsetFields(t *testing.T) {
+	backoff := PollingStrategy{InitialInterval: 10 * time.Second}.NewBackoff()
+
+	assert.Equal(t, 10*time.Second, backoff.Next(0))
+	assert.Equal(t, 15*time.Second, backoff.Next(0))
+	assert.Equal(t, 22500*time.Millisecond, backoff.Next(0))
+	assert.Equal(t, 30*time.Second, backoff.Next(0))
+	assert.Equal(t, 30*time.Second, backoff.Next(0))
+}
+
+func TestPollingBackoff_outOfRangeValuesAreClamped(t *testing.T) {
+	backoff := PollingStrategy{
+		InitialInterval: 2 * time.Second,
+		Multiplier:      0.5,
+		MaxInterval:     1 * time.Second,
+		Jitter:          3,
+	}.NewBackoff()
+
+	assert.Equal(t, 2*time.Second, backoff.Next(0))
+	assert.Equal(t, 2*time.Second, backoff.Next(0))
+}
+
+func TestLegacyPollingStrategy(t *testing.T) {
+	backoff := NewLegacyPollingStrategy().NewBackoff()
+
+	for range 5 {
+		assert.Equal(t, 1*time.Second, backoff.Next(0))
+	}
+}
@@ -1,12 +1,25 @@
 package scan
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ResultMetaData struct {
 	FindingsUrl string
 	WebUiUrl    string
 	ProjectId   string
 	SnapshotId  string
+	TestId      string
+	// TestResult is the outcome of the test and CreatedAt the time the test was created. Both are only set for
+	// tests run through the test service.
+	TestResult TestResult
+	CreatedAt  time.Time
+	// SeverityCounts is the number of findings per severity summed over all components.
+	SeverityCounts SeverityCounts
+	// Components holds the outcome of every scanner that ran as part of the test. It is only populated for tests
+	// run through the test service.
+	Components []TestComponent
 }
 
 type ScanSource string
@@ -33,3 +46,21 @@ func ScanSourceFromContext(ctx context.Context) (ScanSource, bool) {
 	s, ok := ctx.Value(scanSourceKey).(ScanSource)
 	return s, ok
 }
+
+// TestStatus is the state of a test in the Snyk Code test service.
+type TestStatus string
+
+const (
+	TestStatusAccepted   TestStatus = "accepted"
+	TestStatusInProgress TestStatus = "in_progress"
+	TestStatusCompleted  TestStatus = "completed"
+	TestStatusError      TestStatus = "error"
+)
+
+// TestResult is the outcome of a completed test in the Snyk Code test service.
+type TestResult string
+
+const (
+	TestResultPassed TestResult = "passed"
+	TestResultFailed TestResult = "failed"
+)
@@ -0,0 +1,50 @@
+package scan
+
+import (
+	"github.com/snyk/code-client-go/cyclonedx"
+	"github.com/snyk/code-client-go/sarif"
+)
+
+// FindingsDocumentType is the format of the findings document of a TestComponent.
+type FindingsDocumentType string
+
+const (
+	FindingsDocumentTypeSarif     FindingsDocumentType = "sarif"
+	FindingsDocumentTypeCycloneDX FindingsDocumentType = "cyclonedx"
+)
+
+// SeverityCounts is the number of findings per severity.
+type SeverityCounts struct {
+	Critical int
+	High     int
+	Medium   int
+	Low      int
+}
+
+// Add returns the sum of both severity counts.
+func (c SeverityCounts) Add(other SeverityCounts) SeverityCounts {
+	return SeverityCounts{
+		Critical: c.Critical + other.Critical,
+		High:     c.High + other.High,
+		Medium:   c.Medium + other.Medium,
+		Low:      c.Low + other.Low,
+	}
+}
+
+// TestComponent is the outcome of one scanner (e.g. sast, secrets or sca) that ran as part of a test.
+// Depending on FindingsDocumentType either Sarif or CycloneDX holds the downloaded findings document; both are nil
+// when the component did not succeed or has no document.
+type TestComponent struct {
+	Id                   string
+	Name                 string
+	Type                 string
+	Success              bool
+	SeverityCounts       SeverityCounts
+	WebUiUrl             string
+	ProjectId            string
+	SnapshotId           string
+	FindingsUrl          string
+	FindingsDocumentType FindingsDocumentType
+	Sarif                *sarif.SarifDocument
+	CycloneDX            *cyclonedx.Document
+}
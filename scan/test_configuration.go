@@ -0,0 +1,20 @@
+package scan
+
+// TestConfiguration is the effective configuration the test service resolved for a test, i.e. the requested
+// configuration merged with the settings of the organization.
+type TestConfiguration struct {
+	Scanners         []string
+	ResultType       string
+	ExclusionGlobs   []string
+	LimitTestToFiles []string
+	Report           bool
+	ProjectId        string
+	ProjectName      string
+	ProjectTags      []string
+	TargetName       string
+	TargetReference  string
+	Initiator        string
+	Origin           string
+	Label            string
+	Labels           map[string]string
+}
@@ -15,6 +15,8 @@
  */
 package scan
 
+import "github.com/snyk/code-client-go/sarif"
+
 //go:generate go tool github.com/golang/mock/mockgen -destination=mocks/tracker.go -source=tracker.go -package mocks
 
 type TrackerFactory interface {
@@ -29,6 +31,19 @@ type Tracker interface {
 type LegacyScanStatus struct {
 	Message    string
 	Percentage int
+	// Timing and Coverage are the progress of the analysis as last reported by the server.
+	Timing   LegacyScanTiming
+	Coverage []sarif.SarifCoverage
+	// PartialResults contains the findings that are already available while the analysis is still running. It is
+	// nil if the server has not reported any findings yet.
+	PartialResults *sarif.SarifDocument
+}
+
+// LegacyScanTiming is the time in milliseconds that the legacy analysis spent on each of its phases.
+type LegacyScanTiming struct {
+	FetchingCode int
+	Queue        int
+	Analysis     int
 }
 
 func NewLegacyScanDoneStatus(message string) LegacyScanStatus {
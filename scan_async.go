@@ -0,0 +1,158 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codeclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/snyk/code-client-go/internal/analysis"
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+// ErrAnalysisCanceled is returned by an AnalysisHandle after Cancel has been called.
+var ErrAnalysisCanceled = errors.New("analysis was canceled")
+
+// AnalysisHandle references a test that was started without waiting for its results.
+//
+// Tests are always started with version 2025-04-07 of the test API. If the scanner falls back to an older version of
+// the test API afterwards, e.g. because another analysis found that version sunset, Status, Configuration and Wait of
+// existing handles fail, as the older version cannot resume tests. The test can then only be rerun.
+type AnalysisHandle struct {
+	testId               string
+	bundleHash           string
+	orgId                string
+	analysisOrchestrator analysis.AnalysisOrchestrator
+	ctx                  context.Context
+	cancel               context.CancelCauseFunc
+}
+
+func newAnalysisHandle(ctx context.Context, orchestrator analysis.AnalysisOrchestrator, orgId string, testId string, bundleHash string) *AnalysisHandle {
+	// the handle outlives the call that started the analysis, so it must not be canceled together with it
+	handleCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	return &AnalysisHandle{
+		testId:               testId,
+		bundleHash:           bundleHash,
+		orgId:                orgId,
+		analysisOrchestrator: orchestrator,
+		ctx:                  handleCtx,
+		cancel:               cancel,
+	}
+}
+
+// TestId returns the id of the test, which can also be used with ResumeAnalysis.
+func (h *AnalysisHandle) TestId() string {
+	return h.testId
+}
+
+// BundleHash returns the hash of the uploaded bundle, or an empty string if the test is not based on a bundle.
+func (h *AnalysisHandle) BundleHash() string {
+	return h.bundleHash
+}
+
+// Status queries the current state of the test. If the test failed, scan.TestStatusError is returned together
+// with the errors reported by the service. If the service is throttling the requests, a scan.ThrottledError with the
+// time to wait before the next call is returned.
+func (h *AnalysisHandle) Status(ctx context.Context) (scan.TestStatus, error) {
+	if h.ctx.Err() != nil {
+		return "", context.Cause(h.ctx)
+	}
+	return h.analysisOrchestrator.GetTestStatus(ctx, h.orgId, h.testId)
+}
+
+// Configuration retrieves the effective configuration the service resolved for the test.
+func (h *AnalysisHandle) Configuration(ctx context.Context) (*scan.TestConfiguration, error) {
+	return h.analysisOrchestrator.GetTestConfiguration(ctx, h.orgId, h.testId)
+}
+
+// Wait blocks until the test has completed and returns its findings. It returns early if ctx is done or the
+// handle is canceled.
+func (h *AnalysisHandle) Wait(ctx context.Context) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+	waitCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(h.ctx, func() {
+		cancel(context.Cause(h.ctx))
+	})
+	defer stop()
+
+	response, metadata, err := h.analysisOrchestrator.ResumeTest(waitCtx, h.orgId, h.testId)
+	if err != nil && waitCtx.Err() != nil {
+		return nil, nil, context.Cause(waitCtx)
+	}
+	return response, metadata, err
+}
+
+// Cancel stops all pending and future Wait and Status calls of the handle. The test service offers no way to
+// abort a test, so the analysis itself keeps running server-side.
+func (h *AnalysisHandle) Cancel() {
+	h.cancel(ErrAnalysisCanceled)
+}
+
+// StartUploadAndAnalyze uploads the files and creates a test for them, returning as soon as the test exists.
+// A nil handle is returned if there is nothing to analyze.
+func (c *codeScanner) StartUploadAndAnalyze(
+	ctx context.Context,
+	requestId string,
+	target scan.Target,
+	files <-chan string,
+	changedFiles map[string]bool,
+	options ...AnalysisOption,
+) (*AnalysisHandle, error) {
+	uploadedBundle, err := c.Upload(ctx, requestId, target, files, changedFiles)
+	if err != nil || uploadedBundle == nil || uploadedBundle.GetBundleHash() == "" {
+		c.logger.Debug().Msg("empty bundle, no Snyk Code analysis")
+		return nil, err
+	}
+
+	cfg := analysis.AnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	orgId := c.config.Organization()
+	testId, err := c.analysisOrchestrator.CreateTest(ctx, orgId, uploadedBundle, target, cfg)
+	err = c.checkCancellationOrLogError(ctx, target.GetPath(), err, "error creating test...")
+	if err != nil {
+		return nil, err
+	}
+
+	return newAnalysisHandle(ctx, c.analysisOrchestrator, orgId, testId, uploadedBundle.GetBundleHash()), nil
+}
+
+// StartAnalyzeRemote creates a test for a remote project, returning as soon as the test exists.
+func (c *codeScanner) StartAnalyzeRemote(ctx context.Context, options ...AnalysisOption) (*AnalysisHandle, error) {
+	cfg := analysis.AnalysisConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	err := c.checkCancellationOrLogError(ctx, "", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	orgId := c.config.Organization()
+	testId, err := c.analysisOrchestrator.CreateTestRemote(ctx, orgId, cfg)
+	err = c.checkCancellationOrLogError(ctx, "", err, "error creating test...")
+	if err != nil {
+		return nil, err
+	}
+
+	return newAnalysisHandle(ctx, c.analysisOrchestrator, orgId, testId, ""), nil
+}
@@ -0,0 +1,154 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package codeclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codeclient "github.com/snyk/code-client-go"
+	"github.com/snyk/code-client-go/bundle"
+	bundleMocks "github.com/snyk/code-client-go/bundle/mocks"
+	confMocks "github.com/snyk/code-client-go/config/mocks"
+	httpmocks "github.com/snyk/code-client-go/http/mocks"
+	mockAnalysis "github.com/snyk/code-client-go/internal/analysis/mocks"
+	"github.com/snyk/code-client-go/internal/deepcode"
+	deepcodeMocks "github.com/snyk/code-client-go/internal/deepcode/mocks"
+	"github.com/snyk/code-client-go/observability/mocks"
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+func TestStartAnalyzeRemote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithErrorReporter(mockErrorReporter),
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	testId := uuid.NewString()
+
+	t.Run("returns a handle for the created test", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().CreateTestRemote(gomock.Any(), "mockOrgId", gomock.Any()).Return(testId, nil)
+		mockAnalysisOrchestrator.EXPECT().GetTestStatus(gomock.Any(), "mockOrgId", testId).Return(scan.TestStatusInProgress, nil)
+		mockAnalysisOrchestrator.EXPECT().ResumeTest(gomock.Any(), "mockOrgId", testId).
+			Return(&sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{TestId: testId}, nil)
+
+		handle, err := codeScanner.StartAnalyzeRemote(t.Context(), codeclient.ReportRemoteTest(uuid.New(), "abc123"))
+		require.NoError(t, err)
+		assert.Equal(t, testId, handle.TestId())
+		assert.Empty(t, handle.BundleHash())
+
+		status, err := handle.Status(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, scan.TestStatusInProgress, status)
+
+		response, metadata, err := handle.Wait(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "COMPLETE", response.Status)
+		assert.Equal(t, testId, metadata.TestId)
+	})
+
+	t.Run("handles orchestrator error", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().CreateTestRemote(gomock.Any(), gomock.Any(), gomock.Any()).Return("", assert.AnError)
+		mockErrorReporter.EXPECT().CaptureError(gomock.Any(), gomock.Any())
+
+		handle, err := codeScanner.StartAnalyzeRemote(t.Context())
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, handle)
+	})
+
+	t.Run("cancel stops waiting", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().CreateTestRemote(gomock.Any(), gomock.Any(), gomock.Any()).Return(testId, nil)
+		mockAnalysisOrchestrator.EXPECT().ResumeTest(gomock.Any(), "mockOrgId", testId).
+			DoAndReturn(func(ctx context.Context, _ string, _ string) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+				<-ctx.Done()
+				return nil, nil, ctx.Err()
+			})
+
+		handle, err := codeScanner.StartAnalyzeRemote(t.Context())
+		require.NoError(t, err)
+
+		go handle.Cancel()
+		response, metadata, err := handle.Wait(t.Context())
+		assert.ErrorIs(t, err, codeclient.ErrAnalysisCanceled)
+		assert.Nil(t, response)
+		assert.Nil(t, metadata)
+
+		status, err := handle.Status(t.Context())
+		assert.ErrorIs(t, err, codeclient.ErrAnalysisCanceled)
+		assert.Empty(t, status)
+	})
+}
+
+func TestStartUploadAndAnalyze(t *testing.T) {
+	baseDir, firstDocPath, _, firstDocContent, _ := setupDocs(t)
+	firstBundle, err := deepcode.BundleFileFrom(firstDocContent, false)
+	require.NoError(t, err)
+	files := map[string]deepcode.BundleFile{firstDocPath: firstBundle}
+
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockInstrumentor := mocks.NewMockInstrumentor(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	logger := zerolog.Nop()
+
+	requestId := uuid.NewString()
+	bundleHash := uuid.NewString()
+	testId := uuid.NewString()
+	target := scan.RepositoryTarget{LocalFilePath: baseDir}
+
+	mockBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), mockInstrumentor, mockErrorReporter, &logger, "testRootPath", bundleHash, files, []string{}, []string{})
+	mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+	mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(mockBundle, nil)
+	mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, mockBundle, files).Return(mockBundle, nil)
+
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+	mockAnalysisOrchestrator.EXPECT().CreateTest(gomock.Any(), "mockOrgId", mockBundle, target, gomock.Any()).Return(testId, nil)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithErrorReporter(mockErrorReporter),
+		codeclient.WithLogger(&logger),
+	)
+
+	handle, err := codeScanner.
+		WithBundleManager(mockBundleManager).
+		WithAnalysisOrchestrator(mockAnalysisOrchestrator).
+		StartUploadAndAnalyze(t.Context(), requestId, target, sliceToChannel([]string{firstDocPath}), map[string]bool{})
+	require.NoError(t, err)
+	assert.Equal(t, testId, handle.TestId())
+	assert.Equal(t, bundleHash, handle.BundleHash())
+}
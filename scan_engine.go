@@ -0,0 +1,205 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codeclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+// EngineType selects the backend that analyses run against.
+type EngineType string
+
+const (
+	// TestServiceEngine runs analyses through the test service. It is the default.
+	TestServiceEngine EngineType = "test_service"
+	// DeeproxyEngine runs legacy analyses through deeproxy, which is also the API implemented by Snyk Code Local
+	// Engine.
+	DeeproxyEngine EngineType = "deeproxy"
+)
+
+// WithEngine selects the AnalysisEngine returned by Engine. It defaults to TestServiceEngine.
+func WithEngine(engineType EngineType) OptionFunc {
+	return func(c *codeScanner) {
+		c.engineType = engineType
+	}
+}
+
+// AnalysisEngine uploads the files of a target and analyses them, independent of the backend that runs the analysis.
+type AnalysisEngine interface {
+	// Analyze returns the findings, the bundle hash and the result metadata. The metadata is nil if the engine does not
+	// provide any.
+	Analyze(
+		ctx context.Context,
+		requestId string,
+		target scan.Target,
+		files <-chan string,
+		changedFiles map[string]bool,
+		options ...EngineOption,
+	) (*sarif.SarifResponse, string, *scan.ResultMetaData, error)
+}
+
+type engineConfig struct {
+	analysisOptions []AnalysisOption
+	legacyOptions   []LegacyAnalysisOption
+	shardKey        string
+	progress        chan<- scan.LegacyScanStatus
+}
+
+type EngineOption func(*engineConfig)
+
+// WithAnalysisOptions configures analyses of the TestServiceEngine. Other engines ignore them and log a warning.
+func WithAnalysisOptions(options ...AnalysisOption) EngineOption {
+	return func(c *engineConfig) {
+		c.analysisOptions = append(c.analysisOptions, options...)
+	}
+}
+
+// WithLegacyAnalysisOptions configures analyses of the DeeproxyEngine. Other engines ignore them.
+func WithLegacyAnalysisOptions(options ...LegacyAnalysisOption) EngineOption {
+	return func(c *engineConfig) {
+		c.legacyOptions = append(c.legacyOptions, options...)
+	}
+}
+
+// WithShardKey routes analyses of the DeeproxyEngine to the shard of the given key, e.g. the hash of the root path of
+// the workspace. Other engines ignore it.
+func WithShardKey(shardKey string) EngineOption {
+	return func(c *engineConfig) {
+		c.shardKey = shardKey
+	}
+}
+
+// WithProgress receives the progress of the analysis. The statuses are sent in the background, so the channel can be
+// read while Analyze runs or after it has returned. The channel is closed after the final status.
+func WithProgress(progress chan<- scan.LegacyScanStatus) EngineOption {
+	return func(c *engineConfig) {
+		c.progress = progress
+	}
+}
+
+func newEngineConfig(options []EngineOption) engineConfig {
+	cfg := engineConfig{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	return cfg
+}
+
+// Engine returns the AnalysisEngine selected with WithEngine.
+func (c *codeScanner) Engine() AnalysisEngine {
+	switch c.engineType {
+	case DeeproxyEngine:
+		return &deeproxyEngine{scanner: c}
+	default:
+		return &testServiceEngine{scanner: c}
+	}
+}
+
+type testServiceEngine struct {
+	scanner *codeScanner
+}
+
+var _ AnalysisEngine = (*testServiceEngine)(nil)
+
+// Analyze runs UploadAndAnalyzeWithOptions. The progress only receives the final status, as the test service reports
+// its progress through the scan.Tracker.
+func (e *testServiceEngine) Analyze(
+	ctx context.Context,
+	requestId string,
+	target scan.Target,
+	files <-chan string,
+	changedFiles map[string]bool,
+	options ...EngineOption,
+) (*sarif.SarifResponse, string, *scan.ResultMetaData, error) {
+	cfg := newEngineConfig(options)
+	response, bundleHash, metaData, err := e.scanner.UploadAndAnalyzeWithOptions(ctx, requestId, target, files, changedFiles, cfg.analysisOptions...)
+	if cfg.progress != nil {
+		done := scan.NewLegacyScanDoneStatus("Analysis complete")
+		if err != nil {
+			done = scan.NewLegacyScanDoneStatus(fmt.Sprintf("Analysis failed: %v", err))
+		}
+		go func() {
+			cfg.progress <- done
+			close(cfg.progress)
+		}()
+	}
+	return response, bundleHash, metaData, err
+}
+
+type deeproxyEngine struct {
+	scanner *codeScanner
+}
+
+var _ AnalysisEngine = (*deeproxyEngine)(nil)
+
+// Analyze runs UploadAndAnalyzeLegacy with the shard key of WithShardKey. Deeproxy does not return any result metadata.
+// The options of WithAnalysisOptions cannot be applied to legacy analyses and are ignored with a warning.
+func (e *deeproxyEngine) Analyze(
+	ctx context.Context,
+	requestId string,
+	target scan.Target,
+	files <-chan string,
+	changedFiles map[string]bool,
+	options ...EngineOption,
+) (*sarif.SarifResponse, string, *scan.ResultMetaData, error) {
+	cfg := newEngineConfig(options)
+	if len(cfg.analysisOptions) > 0 {
+		e.scanner.logger.Warn().Int("ignoredOptions", len(cfg.analysisOptions)).Msg("the deeproxy engine does not support analysis options, e.g. exclusion globs or scanners, they are ignored")
+	}
+
+	// the status channel must be drained, as the legacy analysis blocks on sending its progress
+	statusChannel := make(chan scan.LegacyScanStatus)
+	go forwardProgress(statusChannel, cfg.progress)
+
+	response, bundleHash, err := e.scanner.UploadAndAnalyzeLegacy(ctx, requestId, target, cfg.shardKey, files, changedFiles, statusChannel, cfg.legacyOptions...)
+	return response, bundleHash, nil, err
+}
+
+// forwardProgress forwards the statuses of in to out until in is closed, then closes out. The statuses are queued, so
+// that a slow reader of out never blocks the sender of in. Without out, the statuses are discarded.
+func forwardProgress(in <-chan scan.LegacyScanStatus, out chan<- scan.LegacyScanStatus) {
+	if out == nil {
+		for range in {
+		}
+		return
+	}
+
+	var pending []scan.LegacyScanStatus
+	for in != nil || len(pending) > 0 {
+		var send chan<- scan.LegacyScanStatus
+		var next scan.LegacyScanStatus
+		if len(pending) > 0 {
+			send = out
+			next = pending[0]
+		}
+		select {
+		case status, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			pending = append(pending, status)
+		case send <- next:
+			pending = pending[1:]
+		}
+	}
+	close(out)
+}
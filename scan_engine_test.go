@@ -0,0 +1,169 @@
+/*
+ * © 2026 Snyk Limited All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codeclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codeclient "github.com/snyk/code-client-go"
+	"github.com/snyk/code-client-go/bundle"
+	bundleMocks "github.com/snyk/code-client-go/bundle/mocks"
+	confMocks "github.com/snyk/code-client-go/config/mocks"
+	httpmocks "github.com/snyk/code-client-go/http/mocks"
+	"github.com/snyk/code-client-go/internal/analysis"
+	mockAnalysis "github.com/snyk/code-client-go/internal/analysis/mocks"
+	"github.com/snyk/code-client-go/internal/deepcode"
+	deepcodeMocks "github.com/snyk/code-client-go/internal/deepcode/mocks"
+	"github.com/snyk/code-client-go/observability/mocks"
+	"github.com/snyk/code-client-go/sarif"
+	"github.com/snyk/code-client-go/scan"
+)
+
+func setupEngine(t *testing.T, engineType codeclient.EngineType) (codeclient.AnalysisEngine, *mockAnalysis.MockAnalysisOrchestrator, scan.Target, <-chan string) {
+	t.Helper()
+	baseDir, firstDocPath, _, firstDocContent, _ := setupDocs(t)
+	firstBundle, err := deepcode.BundleFileFrom(firstDocContent, false)
+	require.NoError(t, err)
+	files := map[string]deepcode.BundleFile{firstDocPath: firstBundle}
+
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+	mockInstrumentor := mocks.NewMockInstrumentor(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	logger := zerolog.Nop()
+
+	mockBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), mockInstrumentor, mockErrorReporter, &logger, baseDir, "bundle-hash", files, []string{}, []string{})
+	mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+	mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(mockBundle, nil)
+	mockBundleManager.EXPECT().Upload(gomock.Any(), "request-id", mockBundle, files).Return(mockBundle, nil)
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	engine := codeclient.NewCodeScanner(
+		mockConfig,
+		httpmocks.NewMockHTTPClient(ctrl),
+		codeclient.WithErrorReporter(mockErrorReporter),
+		codeclient.WithLogger(&logger),
+		codeclient.WithEngine(engineType),
+	).
+		WithBundleManager(mockBundleManager).
+		WithAnalysisOrchestrator(mockAnalysisOrchestrator).
+		Engine()
+	return engine, mockAnalysisOrchestrator, scan.RepositoryTarget{LocalFilePath: baseDir}, sliceToChannel([]string{firstDocPath})
+}
+
+func collectProgress(progress <-chan scan.LegacyScanStatus) <-chan []scan.LegacyScanStatus {
+	collected := make(chan []scan.LegacyScanStatus, 1)
+	go func() {
+		var statuses []scan.LegacyScanStatus
+		for status := range progress {
+			statuses = append(statuses, status)
+		}
+		collected <- statuses
+	}()
+	return collected
+}
+
+func TestEngine_TestService(t *testing.T) {
+	engine, mockAnalysisOrchestrator, target, files := setupEngine(t, codeclient.TestServiceEngine)
+	testId := uuid.NewString()
+	mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockAnalysisOrchestrator.EXPECT().RunTest(gomock.Any(), "mockOrgId", gomock.Any(), target, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ bundle.Bundle, _ scan.Target, cfg analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+			assert.Equal(t, "pr_check", *cfg.Initiator)
+			return &sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{TestId: testId}, nil
+		})
+
+	progress := make(chan scan.LegacyScanStatus)
+	collected := collectProgress(progress)
+	response, bundleHash, metaData, err := engine.Analyze(t.Context(), "request-id", target, files, map[string]bool{},
+		codeclient.WithAnalysisOptions(codeclient.WithInitiator("pr_check")),
+		codeclient.WithLegacyAnalysisOptions(codeclient.WithLegacySeverity(codeclient.LegacySeverityHigh)),
+		codeclient.WithProgress(progress),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+	assert.Equal(t, "bundle-hash", bundleHash)
+	assert.Equal(t, testId, metaData.TestId)
+	statuses := <-collected
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "Analysis complete", statuses[0].Message)
+}
+
+func TestEngine_Deeproxy(t *testing.T) {
+	engine, mockAnalysisOrchestrator, target, files := setupEngine(t, codeclient.DeeproxyEngine)
+	mockAnalysisOrchestrator.EXPECT().RunTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), "bundle-hash", "shard-key", gomock.Any(), int(codeclient.LegacySeverityHigh), false).
+		Return(&sarif.SarifResponse{Status: "COMPLETE"}, scan.LegacyScanStatus{Message: analysis.StatusComplete, Percentage: 100}, nil)
+
+	progress := make(chan scan.LegacyScanStatus)
+	collected := collectProgress(progress)
+	response, bundleHash, metaData, err := engine.Analyze(t.Context(), "request-id", target, files, map[string]bool{},
+		codeclient.WithAnalysisOptions(codeclient.WithInitiator("pr_check")),
+		codeclient.WithLegacyAnalysisOptions(codeclient.WithLegacySeverity(codeclient.LegacySeverityHigh)),
+		codeclient.WithShardKey("shard-key"),
+		codeclient.WithProgress(progress),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+	assert.Equal(t, "bundle-hash", bundleHash)
+	assert.Nil(t, metaData)
+	statuses := <-collected
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "Analysis complete", statuses[0].Message)
+}
+
+func TestEngine_DeeproxyWithoutProgress(t *testing.T) {
+	engine, mockAnalysisOrchestrator, target, files := setupEngine(t, codeclient.DeeproxyEngine)
+	mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), "bundle-hash", "", gomock.Any(), 0, false).
+		Return(&sarif.SarifResponse{Status: "COMPLETE"}, scan.LegacyScanStatus{Message: analysis.StatusComplete, Percentage: 100}, nil)
+
+	response, _, _, err := engine.Analyze(t.Context(), "request-id", target, files, map[string]bool{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+}
+
+func TestEngine_ProgressReadAfterAnalyze(t *testing.T) {
+	for _, engineType := range []codeclient.EngineType{codeclient.TestServiceEngine, codeclient.DeeproxyEngine} {
+		t.Run(string(engineType), func(t *testing.T) {
+			engine, mockAnalysisOrchestrator, target, files := setupEngine(t, engineType)
+			mockAnalysisOrchestrator.EXPECT().RunTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{}, nil).AnyTimes()
+			mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&sarif.SarifResponse{Status: "COMPLETE"}, scan.LegacyScanStatus{Message: analysis.StatusComplete, Percentage: 100}, nil).AnyTimes()
+
+			// the unbuffered channel is only read once Analyze has returned
+			progress := make(chan scan.LegacyScanStatus)
+			_, _, _, err := engine.Analyze(t.Context(), "request-id", target, files, map[string]bool{}, codeclient.WithProgress(progress))
+			require.NoError(t, err)
+
+			statuses := <-collectProgress(progress)
+			require.Len(t, statuses, 1)
+			assert.Equal(t, "Analysis complete", statuses[0].Message)
+		})
+	}
+}
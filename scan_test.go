@@ -16,9 +16,11 @@
 package codeclient_test
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -32,9 +34,11 @@ import (
 	bundleMocks "github.com/snyk/code-client-go/bundle/mocks"
 	confMocks "github.com/snyk/code-client-go/config/mocks"
 	httpmocks "github.com/snyk/code-client-go/http/mocks"
+	"github.com/snyk/code-client-go/internal/analysis"
 	mockAnalysis "github.com/snyk/code-client-go/internal/analysis/mocks"
 	"github.com/snyk/code-client-go/internal/deepcode"
 	deepcodeMocks "github.com/snyk/code-client-go/internal/deepcode/mocks"
+	"github.com/snyk/code-client-go/observability"
 	"github.com/snyk/code-client-go/observability/mocks"
 	"github.com/snyk/code-client-go/sarif"
 	"github.com/snyk/code-client-go/scan"
@@ -195,6 +199,180 @@ func Test_UploadAndAnalyze(t *testing.T) {
 			assert.Equal(t, "COMPLETE", response.Status)
 		},
 	)
+
+	t.Run(
+		"should pass the legacy options to the legacy analysis", func(t *testing.T) {
+			requestId := uuid.NewString()
+			mockBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), mockInstrumentor, mockErrorReporter, &logger, "testRootPath", uuid.NewString(), files, []string{}, []string{})
+			mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+			mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(mockBundle, nil)
+			mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, mockBundle, files).Return(mockBundle, nil)
+
+			mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+			mockAnalysisOrchestrator.EXPECT().RunLegacyTest(
+				gomock.Any(),
+				mockBundle.GetBundleHash(),
+				"shard",
+				gomock.Any(),
+				int(codeclient.LegacySeverityHigh),
+				true,
+			).Return(&sarif.SarifResponse{Status: "COMPLETE"}, scan.LegacyScanStatus{Message: analysis.StatusComplete}, nil)
+
+			codeScanner := codeclient.NewCodeScanner(
+				mockConfig,
+				mockHTTPClient,
+				codeclient.WithTrackerFactory(mockTrackerFactory),
+				codeclient.WithInstrumentor(mockInstrumentor),
+				codeclient.WithErrorReporter(mockErrorReporter),
+				codeclient.WithLogger(&logger),
+			)
+
+			statusChannel := make(chan scan.LegacyScanStatus)
+			go func() {
+				for range statusChannel {
+				}
+			}()
+			response, bundleHash, err := codeScanner.
+				WithBundleManager(mockBundleManager).
+				WithAnalysisOrchestrator(mockAnalysisOrchestrator).
+				UploadAndAnalyzeLegacy(t.Context(), requestId, target, "shard", docs, map[string]bool{}, statusChannel,
+					codeclient.WithLegacySeverity(codeclient.LegacySeverityHigh),
+					codeclient.WithLegacyPrioritized(),
+				)
+			require.NoError(t, err)
+			assert.Equal(t, "COMPLETE", response.Status)
+			assert.Equal(t, mockBundle.GetBundleHash(), bundleHash)
+		},
+	)
+
+	t.Run(
+		"should send partial legacy results through the status channel", func(t *testing.T) {
+			requestId := uuid.NewString()
+			mockConfig.EXPECT().SnykCodeAnalysisTimeout().Return(time.Minute).AnyTimes()
+			mockBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), mockInstrumentor, mockErrorReporter, &logger, "testRootPath", uuid.NewString(), files, []string{}, []string{})
+			mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+			mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(mockBundle, nil)
+			mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, mockBundle, files).Return(mockBundle, nil)
+
+			partialResults := &sarif.SarifDocument{Runs: []sarif.Run{{Results: []sarif.Result{{RuleID: "java/Sqli"}}}}}
+			timing := scan.LegacyScanTiming{FetchingCode: 10, Analysis: 200}
+			mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+			gomock.InOrder(
+				mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, scan.LegacyScanStatus{Message: analysis.StatusAnalyzing, Percentage: 40, PartialResults: partialResults}, nil),
+				mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&sarif.SarifResponse{Status: "COMPLETE"}, scan.LegacyScanStatus{Message: analysis.StatusComplete, Percentage: 100, Timing: timing}, nil),
+			)
+
+			codeScanner := codeclient.NewCodeScanner(
+				mockConfig,
+				mockHTTPClient,
+				codeclient.WithTrackerFactory(mockTrackerFactory),
+				codeclient.WithInstrumentor(mockInstrumentor),
+				codeclient.WithErrorReporter(mockErrorReporter),
+				codeclient.WithLogger(&logger),
+				codeclient.WithPollingStrategy(scan.PollingStrategy{InitialInterval: time.Millisecond}),
+			)
+
+			statusChannel := make(chan scan.LegacyScanStatus)
+			statuses := make(chan []scan.LegacyScanStatus)
+			go func() {
+				var received []scan.LegacyScanStatus
+				for status := range statusChannel {
+					received = append(received, status)
+				}
+				statuses <- received
+			}()
+			_, _, err := codeScanner.
+				WithBundleManager(mockBundleManager).
+				WithAnalysisOrchestrator(mockAnalysisOrchestrator).
+				UploadAndAnalyzeLegacy(t.Context(), requestId, target, "", docs, map[string]bool{}, statusChannel)
+			require.NoError(t, err)
+
+			received := <-statuses
+			require.Len(t, received, 2)
+			assert.Same(t, partialResults, received[0].PartialResults)
+			assert.Equal(t, "Analysis complete", received[1].Message)
+			assert.Equal(t, timing, received[1].Timing)
+		},
+	)
+
+	t.Run(
+		"should stop polling the legacy analysis once the context is cancelled", func(t *testing.T) {
+			requestId := uuid.NewString()
+			mockConfig.EXPECT().SnykCodeAnalysisTimeout().Return(time.Minute).AnyTimes()
+			mockBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), mockInstrumentor, mockErrorReporter, &logger, "testRootPath", uuid.NewString(), files, []string{}, []string{})
+			mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+			mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(mockBundle, nil)
+			mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, mockBundle, files).Return(mockBundle, nil)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+			mockAnalysisOrchestrator.EXPECT().RunLegacyTest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(context.Context, string, string, []string, int, bool) (*sarif.SarifResponse, scan.LegacyScanStatus, error) {
+					cancel()
+					return nil, scan.LegacyScanStatus{Message: analysis.StatusAnalyzing}, nil
+				})
+
+			codeScanner := codeclient.NewCodeScanner(
+				mockConfig,
+				mockHTTPClient,
+				codeclient.WithTrackerFactory(mockTrackerFactory),
+				codeclient.WithInstrumentor(mockInstrumentor),
+				codeclient.WithErrorReporter(mockErrorReporter),
+				codeclient.WithLogger(&logger),
+				codeclient.WithPollingStrategy(scan.PollingStrategy{InitialInterval: time.Minute}),
+			)
+
+			statusChannel := make(chan scan.LegacyScanStatus)
+			go func() {
+				for range statusChannel {
+				}
+			}()
+			_, _, err := codeScanner.
+				WithBundleManager(mockBundleManager).
+				WithAnalysisOrchestrator(mockAnalysisOrchestrator).
+				UploadAndAnalyzeLegacy(ctx, requestId, target, "", docs, map[string]bool{}, statusChannel)
+			assert.ErrorIs(t, err, context.Canceled)
+		},
+	)
+
+	t.Run(
+		"should reject legacy analysis of upload revisions", func(t *testing.T) {
+			codeScanner := codeclient.NewCodeScanner(
+				mockConfig,
+				mockHTTPClient,
+				codeclient.WithTrackerFactory(mockTrackerFactory),
+				codeclient.WithInstrumentor(mockInstrumentor),
+				codeclient.WithErrorReporter(mockErrorReporter),
+				codeclient.WithLogger(&logger),
+				codeclient.WithUploader(codeclient.UploadRevisionUploader),
+			)
+
+			statusChannel := make(chan scan.LegacyScanStatus)
+			response, bundleHash, err := codeScanner.UploadAndAnalyzeLegacy(t.Context(), uuid.NewString(), target, "", docs, map[string]bool{}, statusChannel)
+			assert.ErrorContains(t, err, "legacy analysis does not support upload revisions")
+			assert.Empty(t, bundleHash)
+			assert.Nil(t, response)
+		},
+	)
+}
+
+func TestParseLegacySeverity(t *testing.T) {
+	for threshold, expected := range map[string]codeclient.LegacySeverity{
+		"low":      codeclient.LegacySeverityLow,
+		"medium":   codeclient.LegacySeverityMedium,
+		"high":     codeclient.LegacySeverityHigh,
+		"critical": codeclient.LegacySeverityHigh,
+		"Medium":   codeclient.LegacySeverityMedium,
+	} {
+		severity, err := codeclient.ParseLegacySeverity(threshold)
+		require.NoError(t, err)
+		assert.Equal(t, expected, severity)
+	}
+
+	_, err := codeclient.ParseLegacySeverity("urgent")
+	assert.ErrorContains(t, err, "invalid severity")
 }
 
 func TestAnalyzeRemote(t *testing.T) {
@@ -254,6 +432,397 @@ func TestAnalyzeRemote(t *testing.T) {
 	})
 }
 
+func TestAnalyzeRemoteRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	integrationId := uuid.New()
+	mockAnalysisOrchestrator.EXPECT().RunTestGitUrlCoordinates(
+		gomock.Any(),
+		"mockOrgId",
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ string, cfg analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+		assert.Equal(t, integrationId, *cfg.IntegrationId)
+		assert.Equal(t, "https://github.com/snyk/code-client-go.git", *cfg.RepoUrl)
+		assert.Equal(t, "abc123", *cfg.Revision)
+		return &sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{}, nil
+	})
+
+	response, metadata, err := codeScanner.AnalyzeRemoteRevision(
+		t.Context(),
+		codeclient.WithGitUrlCoordinates(integrationId, "https://github.com/snyk/code-client-go.git", "abc123"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+	assert.NotNil(t, metadata)
+}
+
+func TestAnalyzeWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	workspaceId := uuid.New()
+	projectTags := []string{"team=platform"}
+	mockAnalysisOrchestrator.EXPECT().RunTestWorkspace(
+		gomock.Any(),
+		"mockOrgId",
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ string, cfg analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+		assert.Equal(t, workspaceId, *cfg.WorkspaceId)
+		assert.Equal(t, projectTags, *cfg.ProjectTags)
+		return &sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{}, nil
+	})
+
+	response, metadata, err := codeScanner.AnalyzeWorkspace(
+		t.Context(),
+		codeclient.WithWorkspace(workspaceId),
+		codeclient.WithProjectTags(&projectTags),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+	assert.NotNil(t, metadata)
+}
+
+func TestAnalysisOptions_OutputMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	mockAnalysisOrchestrator.EXPECT().RunTestWorkspace(
+		gomock.Any(),
+		"mockOrgId",
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ string, cfg analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+		assert.Equal(t, "nightly", *cfg.Label)
+		assert.Equal(t, map[string]string{"pipeline_id": "1234", "owner": "security"}, *cfg.Labels)
+		assert.Equal(t, "github", *cfg.Origin)
+		return &sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{}, nil
+	})
+
+	_, _, err := codeScanner.AnalyzeWorkspace(
+		t.Context(),
+		codeclient.WithWorkspace(uuid.New()),
+		codeclient.WithLabel("nightly"),
+		codeclient.WithLabels(map[string]string{"pipeline_id": "1234", "owner": "platform"}),
+		codeclient.WithLabels(map[string]string{"owner": "security"}),
+		codeclient.WithOrigin("github"),
+	)
+	require.NoError(t, err)
+}
+
+func TestUploadAndAnalyzeWithSbom(t *testing.T) {
+	baseDir, firstDocPath, _, _, _ := setupDocs(t)
+	sbomFilePath := filepath.Join(baseDir, "bom.json")
+	require.NoError(t, os.WriteFile(sbomFilePath, []byte(`{"bomFormat": "CycloneDX"}`), 0600))
+	logger := zerolog.Nop()
+
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	target := scan.RepositoryTarget{LocalFilePath: baseDir}
+	requestId := uuid.NewString()
+
+	sourceBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), observability.NewInstrumentor(), mockErrorReporter, &logger, baseDir, "source-hash", map[string]deepcode.BundleFile{}, []string{}, []string{})
+	sbomBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), observability.NewInstrumentor(), mockErrorReporter, &logger, baseDir, "sbom-hash", map[string]deepcode.BundleFile{}, []string{}, []string{})
+	mockBundleManager := bundleMocks.NewMockUnfilteredBundleManager(ctrl)
+	mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(sourceBundle, nil)
+	mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, sourceBundle, gomock.Any()).Return(sourceBundle, nil)
+	mockBundleManager.EXPECT().CreateEmptyUnfiltered(gomock.Any(), baseDir, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, filePaths <-chan string) (bundle.Bundle, error) {
+			var paths []string
+			for path := range filePaths {
+				paths = append(paths, path)
+			}
+			assert.Equal(t, []string{sbomFilePath}, paths)
+			return sbomBundle, nil
+		})
+	mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, sbomBundle, gomock.Any()).Return(sbomBundle, nil)
+
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+	mockAnalysisOrchestrator.EXPECT().RunTestSbom(
+		gomock.Any(),
+		"mockOrgId",
+		sourceBundle,
+		sbomBundle,
+		target,
+		sbomFilePath,
+		gomock.Any(),
+	).Return(&sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{Components: []scan.TestComponent{{Type: "sast"}, {Type: "sbom"}}}, nil)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+		codeclient.WithErrorReporter(mockErrorReporter),
+	).WithBundleManager(mockBundleManager).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	response, metadata, err := codeScanner.UploadAndAnalyzeWithSbom(t.Context(), requestId, target, sbomFilePath, sliceToChannel([]string{firstDocPath}), map[string]bool{})
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+	assert.Len(t, metadata.Components, 2)
+}
+
+func TestUploadAndAnalyzeWithSbom_RequiresUnfilteredBundleManager(t *testing.T) {
+	baseDir, firstDocPath, _, _, _ := setupDocs(t)
+	sbomFilePath := filepath.Join(baseDir, "bom.json")
+	logger := zerolog.Nop()
+
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	target := scan.RepositoryTarget{LocalFilePath: baseDir}
+	requestId := uuid.NewString()
+
+	sourceBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), observability.NewInstrumentor(), mockErrorReporter, &logger, baseDir, "source-hash", map[string]deepcode.BundleFile{}, []string{}, []string{})
+	mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+	mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(sourceBundle, nil)
+	mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, sourceBundle, gomock.Any()).Return(sourceBundle, nil)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+		codeclient.WithErrorReporter(mockErrorReporter),
+	).WithBundleManager(mockBundleManager)
+
+	_, _, err := codeScanner.UploadAndAnalyzeWithSbom(t.Context(), requestId, target, sbomFilePath, sliceToChannel([]string{firstDocPath}), map[string]bool{})
+	assert.ErrorContains(t, err, "cannot bundle SBOM documents")
+}
+
+func TestAnalyzeDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	targetId := uuid.New()
+	response := &sarif.SarifResponse{Status: "COMPLETE"}
+	response.Sarif.Runs = []sarif.Run{{Results: []sarif.Result{
+		{RuleID: "introduced", BaselineState: sarif.BaselineStateNew},
+		{RuleID: "preexisting", BaselineState: sarif.BaselineStateUnchanged},
+	}}}
+	mockAnalysisOrchestrator.EXPECT().RunTestDiff(
+		gomock.Any(),
+		"mockOrgId",
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ string, cfg analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+		assert.Equal(t, targetId, *cfg.TargetId)
+		assert.Equal(t, "base", *cfg.BaseVersion)
+		assert.Equal(t, "head", *cfg.HeadVersion)
+		assert.Equal(t, "pr_check", *cfg.Initiator)
+		return response, &scan.ResultMetaData{}, nil
+	})
+
+	result, classified, metadata, err := codeScanner.AnalyzeDiff(
+		t.Context(),
+		codeclient.WithDiffTarget(targetId, "base", "head"),
+		codeclient.WithInitiator("pr_check"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, response, result)
+	assert.NotNil(t, metadata)
+	require.Len(t, classified.New, 1)
+	assert.Equal(t, "introduced", classified.New[0].RuleID)
+	require.Len(t, classified.Existing, 1)
+	assert.Equal(t, "preexisting", classified.Existing[0].RuleID)
+}
+
+func TestUploadAndAnalyzeStream(t *testing.T) {
+	baseDir, firstDocPath, _, firstDocContent, _ := setupDocs(t)
+	firstBundle, err := deepcode.BundleFileFrom(firstDocContent, false)
+	require.NoError(t, err)
+	files := map[string]deepcode.BundleFile{firstDocPath: firstBundle}
+	logger := zerolog.Nop()
+
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockInstrumentor := mocks.NewMockInstrumentor(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+
+	requestId := uuid.NewString()
+	target := scan.RepositoryTarget{LocalFilePath: baseDir}
+	mockBundle := bundle.NewBundle(deepcodeMocks.NewMockDeepcodeClient(ctrl), mockInstrumentor, mockErrorReporter, &logger, "testRootPath", uuid.NewString(), files, []string{}, []string{})
+	mockBundleManager := bundleMocks.NewMockBundleManager(ctrl)
+	mockBundleManager.EXPECT().CreateEmpty(gomock.Any(), baseDir, gomock.Any(), map[string]bool{}).Return(mockBundle, nil)
+	mockBundleManager.EXPECT().Upload(gomock.Any(), requestId, mockBundle, files).Return(mockBundle, nil)
+
+	var streamed []string
+	handler := sarif.StreamHandler{OnResult: func(_ int, result sarif.Result) error {
+		streamed = append(streamed, result.RuleID)
+		return nil
+	}}
+
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+	mockAnalysisOrchestrator.EXPECT().RunTest(gomock.Any(), "mockOrgId", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ bundle.Bundle, _ scan.Target, cfg analysis.AnalysisConfig) (*sarif.SarifResponse, *scan.ResultMetaData, error) {
+			require.NotNil(t, cfg.StreamHandler)
+			assert.Equal(t, "my-project", *cfg.ProjectName)
+			require.NoError(t, cfg.StreamHandler.OnResult(0, sarif.Result{RuleID: "rule-1"}))
+			return &sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{}, nil
+		})
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithLogger(&logger),
+	).WithBundleManager(mockBundleManager).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	response, bundleHash, _, err := codeScanner.UploadAndAnalyzeStream(t.Context(), requestId, target, sliceToChannel([]string{firstDocPath}), map[string]bool{}, handler,
+		codeclient.ReportLocalTest("my-project", "", ""))
+
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", response.Status)
+	assert.Equal(t, mockBundle.GetBundleHash(), bundleHash)
+	assert.Equal(t, []string{"rule-1"}, streamed)
+}
+
+func TestGetTestConfiguration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithErrorReporter(mockErrorReporter),
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	testId := uuid.NewString()
+
+	t.Run("returns the configuration of the test", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().GetTestConfiguration(
+			gomock.Any(),
+			"mockOrgId",
+			testId,
+		).Return(&scan.TestConfiguration{Scanners: []string{"sast"}}, nil)
+
+		configuration, err := codeScanner.GetTestConfiguration(t.Context(), testId)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sast"}, configuration.Scanners)
+	})
+
+	t.Run("handles orchestrator error", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().GetTestConfiguration(
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, assert.AnError)
+		mockErrorReporter.EXPECT().CaptureError(gomock.Any(), gomock.Any())
+
+		configuration, err := codeScanner.GetTestConfiguration(t.Context(), testId)
+		assert.Nil(t, configuration)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("does not retrieve the configuration when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := codeScanner.GetTestConfiguration(ctx, testId)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestResumeAnalysis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockConfig := confMocks.NewMockConfig(ctrl)
+	mockConfig.EXPECT().Organization().AnyTimes().Return("mockOrgId")
+
+	mockHTTPClient := httpmocks.NewMockHTTPClient(ctrl)
+	mockErrorReporter := mocks.NewMockErrorReporter(ctrl)
+	logger := zerolog.Nop()
+	mockAnalysisOrchestrator := mockAnalysis.NewMockAnalysisOrchestrator(ctrl)
+
+	codeScanner := codeclient.NewCodeScanner(
+		mockConfig,
+		mockHTTPClient,
+		codeclient.WithErrorReporter(mockErrorReporter),
+		codeclient.WithLogger(&logger),
+	).WithAnalysisOrchestrator(mockAnalysisOrchestrator)
+
+	testId := uuid.NewString()
+
+	t.Run("returns findings of the existing test", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().ResumeTest(
+			gomock.Any(),
+			"mockOrgId",
+			testId,
+		).Return(&sarif.SarifResponse{Status: "COMPLETE"}, &scan.ResultMetaData{TestId: testId}, nil)
+
+		response, metadata, err := codeScanner.ResumeAnalysis(t.Context(), testId)
+		require.NoError(t, err)
+		assert.Equal(t, "COMPLETE", response.Status)
+		assert.Equal(t, testId, metadata.TestId)
+	})
+
+	t.Run("handles orchestrator error", func(t *testing.T) {
+		mockAnalysisOrchestrator.EXPECT().ResumeTest(
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, nil, assert.AnError)
+		mockErrorReporter.EXPECT().CaptureError(gomock.Any(), gomock.Any())
+
+		response, metadata, err := codeScanner.ResumeAnalysis(t.Context(), testId)
+		assert.Nil(t, response)
+		assert.Nil(t, metadata)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 func setupDocs(t *testing.T) (string, string, string, []byte, []byte) {
 	t.Helper()
 	path := t.TempDir()